@@ -0,0 +1,279 @@
+package gotestguide
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////
+// BuildMetadata
+////////////////////////////////////////////////////////////
+
+// Information about the CI build and the version control state a report was created in.
+// Fields which could not be detected are empty.
+type BuildMetadata struct {
+	CISystem    CISystem
+	BuildNumber string
+	BuildURL    string
+	JobName     string
+	// Unique ID of the pipeline run
+	PipelineID string
+	Repository string
+	Commit     string
+	Branch     string
+	Tag        string
+	// Whether the working tree has uncommitted changes
+	Dirty bool
+}
+
+func (m *BuildMetadata) String() string {
+	return fmt.Sprintf("BuildMetadata(CISystem: %s, BuildNumber: %s, PipelineID: %s, Commit: %s, Branch: %s, Tag: %s, Dirty: %t)",
+		m.CISystem, m.BuildNumber, m.PipelineID, m.Commit, m.Branch, m.Tag, m.Dirty)
+}
+
+// Gets the non-empty metadata as key value pairs in a stable order.
+func (m *BuildMetadata) values() [][2]string {
+	values := [][2]string{
+		{"CI_SYSTEM", string(m.CISystem)},
+		{"CI_BUILD_NUMBER", m.BuildNumber},
+		{"CI_BUILD_URL", m.BuildURL},
+		{"CI_JOB_NAME", m.JobName},
+		{"CI_PIPELINE_ID", m.PipelineID},
+		{"GIT_REPOSITORY", m.Repository},
+		{"GIT_COMMIT", m.Commit},
+		{"GIT_BRANCH", m.Branch},
+		{"GIT_TAG", m.Tag},
+	}
+	if m.Commit != "" {
+		values = append(values, [2]string{"GIT_DIRTY", fmt.Sprint(m.Dirty)})
+	}
+	ret := [][2]string{}
+	for _, value := range values {
+		if value[1] != "" {
+			ret = append(ret, value)
+		}
+	}
+	return ret
+}
+
+// Gets the metadata as constants.
+func (m *BuildMetadata) Constants() []*Constant {
+	constants := []*Constant{}
+	for _, value := range m.values() {
+		constants = append(constants, &Constant{Key: value[0], Value: value[1]})
+	}
+	return constants
+}
+
+// Gets the metadata as attributes.
+func (m *BuildMetadata) Attributes() []*Attribute {
+	attributes := []*Attribute{}
+	for _, value := range m.values() {
+		attributes = append(attributes, &Attribute{Key: value[0], Value: value[1]})
+	}
+	return attributes
+}
+
+// Adds the metadata as constants (and optionally as attributes) to all test cases of the report.
+// Keys which are already set on a test case are not overwritten.
+func (m *BuildMetadata) ApplyTo(report *UploadReport, asAttributes bool) {
+	for _, testCase := range report.GetAllTestCases() {
+		for _, constant := range m.Constants() {
+			if !hasConstant(testCase.Constants, constant.Key) {
+				testCase.Constants = append(testCase.Constants, constant)
+			}
+		}
+		if asAttributes {
+			for _, attribute := range m.Attributes() {
+				if !hasAttribute(testCase.Attributes, attribute.Key) {
+					testCase.Attributes = append(testCase.Attributes, attribute)
+				}
+			}
+		}
+	}
+}
+
+func hasConstant(constants []*Constant, key string) bool {
+	for _, constant := range constants {
+		if constant.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAttribute(attributes []*Attribute, key string) bool {
+	for _, attribute := range attributes {
+		if attribute.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+////////////////////////////////////////////////////////////
+// Detection
+////////////////////////////////////////////////////////////
+
+// Detects the CI system from the environment variables and reads the git metadata of the working tree in the given directory.
+// The values of the CI system take precedence, as CI checkouts are often on a detached HEAD.
+func DetectBuildMetadata(dir string) *BuildMetadata {
+	metadata := detectCIMetadata(os.Getenv)
+	gitMetadata := readGitMetadata(dir)
+	if metadata.Commit == "" {
+		metadata.Commit = gitMetadata.Commit
+	}
+	if metadata.Branch == "" {
+		metadata.Branch = gitMetadata.Branch
+	}
+	if metadata.Tag == "" {
+		metadata.Tag = gitMetadata.Tag
+	}
+	if metadata.Repository == "" {
+		metadata.Repository = gitMetadata.Repository
+	}
+	metadata.Dirty = gitMetadata.Dirty
+	metadata.Repository = stripUrlCredentials(metadata.Repository)
+	return metadata
+}
+
+// Detects the CI system and its build information from the environment variables.
+func detectCIMetadata(getenv func(string) string) *BuildMetadata {
+	switch {
+	case getenv("GITHUB_ACTIONS") == "true":
+		metadata := &BuildMetadata{
+			CISystem:    CI_SYSTEM_GITHUB_ACTIONS,
+			BuildNumber: getenv("GITHUB_RUN_NUMBER"),
+			JobName:     getenv("GITHUB_WORKFLOW"),
+			PipelineID:  getenv("GITHUB_RUN_ID"),
+			Repository:  getenv("GITHUB_REPOSITORY"),
+			Commit:      getenv("GITHUB_SHA"),
+			Branch:      getenv("GITHUB_HEAD_REF"),
+		}
+		if metadata.Repository != "" && metadata.PipelineID != "" {
+			metadata.BuildURL = fmt.Sprintf("%s/%s/actions/runs/%s", getenv("GITHUB_SERVER_URL"), metadata.Repository, metadata.PipelineID)
+		}
+		if getenv("GITHUB_REF_TYPE") == "tag" {
+			metadata.Tag = getenv("GITHUB_REF_NAME")
+		} else if metadata.Branch == "" {
+			metadata.Branch = getenv("GITHUB_REF_NAME")
+		}
+		return metadata
+	case getenv("GITLAB_CI") == "true":
+		metadata := &BuildMetadata{
+			CISystem:    CI_SYSTEM_GITLAB_CI,
+			BuildNumber: getenv("CI_PIPELINE_IID"),
+			BuildURL:    getenv("CI_PIPELINE_URL"),
+			JobName:     getenv("CI_JOB_NAME"),
+			PipelineID:  getenv("CI_PIPELINE_ID"),
+			Repository:  getenv("CI_PROJECT_PATH"),
+			Commit:      getenv("CI_COMMIT_SHA"),
+			Branch:      getenv("CI_COMMIT_BRANCH"),
+			Tag:         getenv("CI_COMMIT_TAG"),
+		}
+		if metadata.Branch == "" {
+			metadata.Branch = getenv("CI_MERGE_REQUEST_SOURCE_BRANCH_NAME")
+		}
+		return metadata
+	case strings.EqualFold(getenv("TF_BUILD"), "true"):
+		metadata := &BuildMetadata{
+			CISystem:    CI_SYSTEM_AZURE_PIPELINES,
+			BuildNumber: getenv("BUILD_BUILDNUMBER"),
+			JobName:     getenv("BUILD_DEFINITIONNAME"),
+			PipelineID:  getenv("BUILD_BUILDID"),
+			Repository:  getenv("BUILD_REPOSITORY_NAME"),
+			Commit:      getenv("BUILD_SOURCEVERSION"),
+		}
+		if collectionUri := getenv("SYSTEM_COLLECTIONURI"); collectionUri != "" && metadata.PipelineID != "" {
+			metadata.BuildURL = fmt.Sprintf("%s%s/_build/results?buildId=%s", collectionUri, getenv("SYSTEM_TEAMPROJECT"), metadata.PipelineID)
+		}
+		sourceBranch := getenv("BUILD_SOURCEBRANCH")
+		if tag, ok := strings.CutPrefix(sourceBranch, "refs/tags/"); ok {
+			metadata.Tag = tag
+		} else if branch := getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH"); branch != "" {
+			metadata.Branch = strings.TrimPrefix(branch, "refs/heads/")
+		} else {
+			metadata.Branch = strings.TrimPrefix(sourceBranch, "refs/heads/")
+		}
+		return metadata
+	case getenv("TEAMCITY_VERSION") != "":
+		metadata := &BuildMetadata{
+			CISystem:    CI_SYSTEM_TEAMCITY,
+			BuildNumber: getenv("BUILD_NUMBER"),
+			BuildURL:    getenv("TEAMCITY_BUILD_URL"),
+			JobName:     getenv("TEAMCITY_BUILDCONF_NAME"),
+			PipelineID:  getenv("BUILD_ID"),
+			Repository:  getenv("TEAMCITY_PROJECT_NAME"),
+			Commit:      getenv("BUILD_VCS_NUMBER"),
+		}
+		if metadata.BuildURL == "" {
+			metadata.BuildURL = getenv("BUILD_URL")
+		}
+		return metadata
+	case getenv("JENKINS_URL") != "":
+		metadata := &BuildMetadata{
+			CISystem:    CI_SYSTEM_JENKINS,
+			BuildNumber: getenv("BUILD_NUMBER"),
+			BuildURL:    getenv("BUILD_URL"),
+			JobName:     getenv("JOB_NAME"),
+			PipelineID:  getenv("BUILD_TAG"),
+			Repository:  getenv("GIT_URL"),
+			Commit:      getenv("GIT_COMMIT"),
+			Branch:      getenv("BRANCH_NAME"),
+			Tag:         getenv("TAG_NAME"),
+		}
+		if metadata.Branch == "" {
+			metadata.Branch = strings.TrimPrefix(getenv("GIT_BRANCH"), "origin/")
+		}
+		return metadata
+	}
+	return &BuildMetadata{}
+}
+
+// Reads the commit, branch, tag, remote and dirty state of the git working tree in the given directory.
+// Returns empty metadata if git is not available or the directory is not in a working tree.
+func readGitMetadata(dir string) *BuildMetadata {
+	metadata := &BuildMetadata{}
+	commit, err := runGit(dir, "rev-parse", "HEAD")
+	if err != nil {
+		return metadata
+	}
+	metadata.Commit = commit
+	if branch, err := runGit(dir, "rev-parse", "--abbrev-ref", "HEAD"); err == nil && branch != "HEAD" {
+		metadata.Branch = branch
+	}
+	if tag, err := runGit(dir, "describe", "--tags", "--exact-match", "HEAD"); err == nil {
+		metadata.Tag = tag
+	}
+	if remote, err := runGit(dir, "config", "--get", "remote.origin.url"); err == nil {
+		metadata.Repository = remote
+	}
+	if status, err := runGit(dir, "status", "--porcelain"); err == nil {
+		metadata.Dirty = status != ""
+	}
+	return metadata
+}
+
+// Removes the user info from URLs so that tokens of remote URLs do not end up in reports.
+func stripUrlCredentials(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil || parsedUrl.User == nil {
+		return rawUrl
+	}
+	parsedUrl.User = nil
+	return parsedUrl.String()
+}
+
+// Runs git in the given directory and returns the trimmed output.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(output)), nil
+}
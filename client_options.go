@@ -0,0 +1,102 @@
+package gotestguide
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// An option to configure the client when creating it with NewClient.
+type ClientOption func(c *Client) error
+
+// Trust the CA certificates from the given PEM file in addition to the system certificates.
+func WithCACertFile(caCertPath string) ClientOption {
+	return func(c *Client) error {
+		pemBytes, err := os.ReadFile(caCertPath)
+		if err != nil {
+			return fmt.Errorf("failed to read CA certificate file %s: %w", caCertPath, err)
+		}
+		return WithCACertPEM(pemBytes)(c)
+	}
+}
+
+// Trust the given PEM encoded CA certificates in addition to the system certificates.
+func WithCACertPEM(pemBytes []byte) ClientOption {
+	return func(c *Client) error {
+		tlsConfig := c.tlsConfig()
+		if tlsConfig.RootCAs == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			tlsConfig.RootCAs = pool
+		}
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pemBytes) {
+			return fmt.Errorf("no valid CA certificate found")
+		}
+		return nil
+	}
+}
+
+// Authenticate with the given client certificate and key (mTLS).
+func WithClientCertificate(certPath string, keyPath string) ClientOption {
+	return func(c *Client) error {
+		cert, err := tls.LoadX509KeyPair(certPath, keyPath)
+		if err != nil {
+			return fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig := c.tlsConfig()
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+		return nil
+	}
+}
+
+// Send all requests through the given proxy instead of the one from the environment.
+// An empty proxyUrl keeps the proxy from the environment (HTTP_PROXY / HTTPS_PROXY).
+// noProxy is a comma-separated list of hosts, domains, IPs or CIDRs which should be accessed directly (same format as NO_PROXY).
+func WithProxy(proxyUrl string, noProxy string) ClientOption {
+	return func(c *Client) error {
+		config := httpproxy.FromEnvironment()
+		if proxyUrl != "" {
+			parsedUrl, err := url.Parse(proxyUrl)
+			if err != nil {
+				return fmt.Errorf("invalid proxy URL: %w", err)
+			}
+			if parsedUrl.Scheme == "" || parsedUrl.Host == "" {
+				return fmt.Errorf("invalid proxy URL %s: scheme and host are required, e.g. http://proxy:3128", proxyUrl)
+			}
+			config.HTTPProxy = proxyUrl
+			config.HTTPSProxy = proxyUrl
+		}
+		config.NoProxy = noProxy
+		proxyFunc := config.ProxyFunc()
+		c.transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+		return nil
+	}
+}
+
+// Disable the verification of the server certificate.
+// This is insecure and should only be used for testing.
+func WithInsecureSkipVerify() ClientOption {
+	return func(c *Client) error {
+		log.Println("WARNING: TLS certificate verification is disabled, the connection to test.guide is insecure")
+		c.tlsConfig().InsecureSkipVerify = true
+		return nil
+	}
+}
+
+// Get the TLS config of the transport, creating it if needed.
+func (c *Client) tlsConfig() *tls.Config {
+	if c.transport.TLSClientConfig == nil {
+		c.transport.TLSClientConfig = &tls.Config{}
+	}
+	return c.transport.TLSClientConfig
+}
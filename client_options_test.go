@@ -0,0 +1,113 @@
+package gotestguide
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientOptions_CACert(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"projectId": 1}`))
+	}))
+	t.Cleanup(server.Close)
+	caPem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	// Execute
+	untrustedClient, err := NewClient(server.URL, "token")
+	assert.NoError(err, "Should not return an error")
+	_, _, untrustedErr := untrustedClient.Platform.GetProject(1)
+	trustedClient, err := NewClient(server.URL, "token", WithCACertPEM(caPem))
+	assert.NoError(err, "Should not return an error")
+	project, _, trustedErr := trustedClient.Platform.GetProject(1)
+
+	// Verify
+	assert.Error(untrustedErr, "Should fail without the CA certificate")
+	assert.NoError(trustedErr, "Should succeed with the CA certificate")
+	assert.Equal(1, project.ID, "Project ID should match expected value")
+}
+
+func TestClientOptions_InsecureSkipVerify(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"projectId": 1}`))
+	}))
+	t.Cleanup(server.Close)
+
+	// Execute
+	client, err := NewClient(server.URL, "token", WithInsecureSkipVerify())
+	assert.NoError(err, "Should not return an error")
+	_, _, err = client.Platform.GetProject(1)
+
+	// Verify
+	assert.NoError(err, "Should succeed without verifying the certificate")
+}
+
+func TestClientOptions_Proxy(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	client, err := NewClient("https://test-guide.example.com", "token", WithProxy("http://proxy.example.com:3128", "internal.example.com,10.0.0.0/8"))
+	assert.NoError(err, "Should not return an error")
+
+	// Execute
+	proxyFor := func(rawUrl string) *url.URL {
+		req, _ := http.NewRequest(http.MethodGet, rawUrl, nil)
+		proxyUrl, err := client.transport.Proxy(req)
+		assert.NoError(err, "Should not return an error")
+		return proxyUrl
+	}
+
+	// Verify
+	assert.Equal("proxy.example.com:3128", proxyFor("https://test-guide.example.com/api").Host, "Should use the proxy")
+	assert.Nil(proxyFor("https://host.internal.example.com/api"), "Should bypass the proxy for NO_PROXY domains")
+	assert.Nil(proxyFor("https://10.1.2.3/api"), "Should bypass the proxy for NO_PROXY CIDRs")
+}
+
+func TestClientOptions_ProxyFromEnvironment(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	t.Setenv("HTTPS_PROXY", "http://env-proxy.example.com:3128")
+	client, err := NewClient("https://test-guide.example.com", "token", WithProxy("", "internal.example.com"))
+	assert.NoError(err, "Should not return an error")
+
+	// Execute
+	proxyFor := func(rawUrl string) *url.URL {
+		req, _ := http.NewRequest(http.MethodGet, rawUrl, nil)
+		proxyUrl, err := client.transport.Proxy(req)
+		assert.NoError(err, "Should not return an error")
+		return proxyUrl
+	}
+
+	// Verify
+	assert.Equal("env-proxy.example.com:3128", proxyFor("https://test-guide.example.com/api").Host, "Should use the proxy from the environment")
+	assert.Nil(proxyFor("https://host.internal.example.com/api"), "Should bypass the proxy for the given hosts")
+}
+
+func TestClientOptions_InvalidProxy(t *testing.T) {
+	for _, proxyUrl := range []string{"proxy:3128", "host", "http://"} {
+		t.Run(proxyUrl, func(t *testing.T) {
+			// Execute
+			_, err := NewClient("https://test-guide.example.com", "token", WithProxy(proxyUrl, ""))
+
+			// Verify
+			assert.ErrorContains(t, err, "invalid proxy URL", "Should reject a proxy without scheme and host")
+		})
+	}
+}
+
+func TestClientOptions_InvalidCACert(t *testing.T) {
+	// Execute
+	_, err := NewClient("https://test-guide.example.com", "token", WithCACertPEM([]byte("not a certificate")))
+
+	// Verify
+	assert.Error(t, err, "Should fail for an invalid CA certificate")
+}
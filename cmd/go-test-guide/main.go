@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	gotestguide "github.com/roemer/go-test-guide"
 	"github.com/roemer/go-test-guide/internal"
@@ -28,6 +31,51 @@ func main() {
 				Usage:   "API token for authenticating with the Test.Guide server",
 				Sources: cli.EnvVars("TEST_GUIDE_TOKEN"),
 			},
+			&cli.StringFlag{
+				Name:    "ca-cert",
+				Usage:   "Path to a PEM file with additional CA certificates to trust",
+				Sources: cli.EnvVars("TEST_GUIDE_CA_CERT"),
+			},
+			&cli.StringFlag{
+				Name:    "client-cert",
+				Usage:   "Path to a PEM client certificate for mutual TLS",
+				Sources: cli.EnvVars("TEST_GUIDE_CLIENT_CERT"),
+			},
+			&cli.StringFlag{
+				Name:    "client-key",
+				Usage:   "Path to the PEM private key of the client certificate",
+				Sources: cli.EnvVars("TEST_GUIDE_CLIENT_KEY"),
+			},
+			&cli.StringFlag{
+				Name:    "proxy",
+				Usage:   "URL of the HTTP proxy to use instead of the one from the environment",
+				Sources: cli.EnvVars("TEST_GUIDE_PROXY"),
+			},
+			&cli.StringFlag{
+				Name:    "no-proxy",
+				Usage:   "Comma-separated list of hosts which should not use the proxy",
+				Sources: cli.EnvVars("TEST_GUIDE_NO_PROXY", "NO_PROXY"),
+			},
+			&cli.BoolFlag{
+				Name:  "insecure",
+				Usage: "Skip the verification of the server certificate (insecure)",
+			},
+			&cli.FloatFlag{
+				Name:  "upload-rate",
+				Usage: "Maximum number of upload requests per second (0 = unlimited)",
+			},
+			&cli.IntFlag{
+				Name:  "upload-concurrency",
+				Usage: "Maximum number of upload requests in flight (0 = unlimited)",
+			},
+			&cli.FloatFlag{
+				Name:  "query-rate",
+				Usage: "Maximum number of query requests per second (0 = unlimited)",
+			},
+			&cli.IntFlag{
+				Name:  "query-concurrency",
+				Usage: "Maximum number of query requests in flight (0 = unlimited)",
+			},
 		},
 		Commands: []*cli.Command{
 			{
@@ -36,8 +84,640 @@ func main() {
 				Usage:   "Manage reports",
 				Commands: []*cli.Command{
 					{
-						Name:  "upload-report",
-						Usage: "Upload a new report",
+						Name:  "upload-report",
+						Usage: "Upload a new report",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "converter",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "report",
+								Usage:    "Path to the report file or to a directory with all files of the report",
+								Required: true,
+							},
+							&cli.StringSliceFlag{
+								Name:  "include",
+								Usage: "Glob pattern of files to include when uploading a directory, can be repeated",
+							},
+							&cli.StringSliceFlag{
+								Name:  "exclude",
+								Usage: "Glob pattern of files to exclude when uploading a directory, can be repeated",
+							},
+							&cli.StringFlag{
+								Name:    "spool-dir",
+								Usage:   "Directory of an upload spool, queues the upload if the server is unreachable",
+								Sources: cli.EnvVars("TEST_GUIDE_SPOOL_DIR"),
+							},
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Wait until the report is processed and print the report ID",
+							},
+						}, append(buildMetadataFlags(), identifierFlags()...)...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								converter := cmd.String("converter")
+								report := cmd.String("report")
+								include := cmd.StringSlice("include")
+								exclude := cmd.StringSlice("exclude")
+								preparation, err := reportPreparationFromFlags(cmd)
+								if err != nil {
+									return err
+								}
+								if cmd.IsSet("spool-dir") {
+									return gotestguideapp.SpoolUploadReport(client, cmd.String("spool-dir"), projectId, converter, report, preparation)
+								}
+								return gotestguideapp.UploadReport(client, projectId, converter, report, include, exclude, preparation, cmd.Bool("wait"))
+							})
+						},
+					},
+					{
+						Name:  "upload-reports",
+						Usage: "Upload many reports concurrently and wait until they are processed",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "converter",
+								Required: true,
+							},
+							&cli.StringSliceFlag{
+								Name:     "report",
+								Usage:    "Path or glob pattern of the reports, can be repeated",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of reports which are uploaded at the same time",
+								Value: 4,
+							},
+							&cli.DurationFlag{
+								Name:  "timeout",
+								Usage: "Maximum time to wait for each upload to be processed (0 = no timeout)",
+							},
+						}, append(buildMetadataFlags(), identifierFlags()...)...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								converter := cmd.String("converter")
+								reports := cmd.StringSlice("report")
+								workers := cmd.Int("workers")
+								timeout := cmd.Duration("timeout")
+								preparation, err := reportPreparationFromFlags(cmd)
+								if err != nil {
+									return err
+								}
+								return gotestguideapp.UploadReports(client, projectId, converter, reports, workers, timeout, preparation)
+							})
+						},
+					},
+					{
+						Name:  "delete-report",
+						Usage: "Delete the report with the given report ID",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "report",
+								Aliases:  []string{"reportId"},
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								reportId := cmd.Int64("report")
+								return gotestguideapp.DeleteReport(client, reportId)
+							})
+						},
+					},
+					{
+						Name:  "add-artifact",
+						Usage: "Add a new artifact",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "tce",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "artifact",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "comment",
+								Usage: "Optional comment of the artifact",
+							},
+							&cli.StringFlag{
+								Name:  "category",
+								Usage: "Optional category of the artifact",
+							},
+							&cli.StringFlag{
+								Name:    "spool-dir",
+								Usage:   "Directory of an upload spool, queues the upload if the server is unreachable",
+								Sources: cli.EnvVars("TEST_GUIDE_SPOOL_DIR"),
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								tceId := cmd.Int64("tce")
+								filePath := cmd.String("artifact")
+								comment := cmd.String("comment")
+								category := cmd.String("category")
+								if cmd.IsSet("spool-dir") {
+									return gotestguideapp.SpoolAddArtifact(client, cmd.String("spool-dir"), tceId, filePath, comment, category)
+								}
+								return gotestguideapp.AddArtifact(client, tceId, filePath, comment, category)
+							})
+						},
+					},
+					{
+						Name:  "create-review",
+						Usage: "Create a review for a test case execution",
+						Flags: append([]cli.Flag{
+							&cli.Int64Flag{
+								Name:     "tce",
+								Required: true,
+							},
+						}, reviewFlags()...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								review, err := reviewFromFlags(cmd)
+								if err != nil {
+									return err
+								}
+								tceId := cmd.Int64("tce")
+								return gotestguideapp.CreateReview(client, tceId, review)
+							})
+						},
+					},
+					{
+						Name:  "get-reviews",
+						Usage: "List the review history of a test case execution",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "tce",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								tceId := cmd.Int64("tce")
+								return gotestguideapp.GetReviews(client, tceId)
+							})
+						},
+					},
+					{
+						Name:  "bulk-review",
+						Usage: "Create the same review for all test case executions matching a filter",
+						Flags: slices.Concat([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of reviews which are created at the same time",
+								Value: 4,
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Only list the test case executions which would be reviewed",
+							},
+						}, filterSourceFlags("Query for the test case executions, e.g. 'verdict:FAILED since:1d'"), reviewFlags()),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								review, err := reviewFromFlags(cmd)
+								if err != nil {
+									return err
+								}
+								projectId := cmd.Int("project")
+								filter, err := gotestguideapp.LoadFilterParameters(client, querySourceFromFlags(cmd))
+								if err != nil {
+									return err
+								}
+								workers := cmd.Int("workers")
+								dryRun := cmd.Bool("dry-run")
+								return gotestguideapp.BulkReview(client, projectId, filter, review, workers, dryRun)
+							})
+						},
+					},
+					{
+						Name:  "export-filters",
+						Usage: "Export all filters of a project into JSON files",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "dir",
+								Usage:    "Directory to write the filter files to",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								dir := cmd.String("dir")
+								return gotestguideapp.ExportFilters(client, projectId, dir)
+							})
+						},
+					},
+					{
+						Name:  "apply-filters",
+						Usage: "Create or update (by name) the filters from JSON files in a project",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "dir",
+								Usage:    "Directory with the filter files",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								dir := cmd.String("dir")
+								return gotestguideapp.ApplyFilters(client, projectId, dir)
+							})
+						},
+					},
+					{
+						Name:  "query",
+						Usage: "List the test case executions of a project filter or query",
+						Flags: append(querySourceFlags(),
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (table, json, ndjson, csv, template)",
+								Value: "table",
+							},
+							&cli.StringFlag{
+								Name:  "template",
+								Usage: "Go text/template which is executed for each test case execution, e.g. '{{.ID}} {{.Verdict}}'",
+							},
+						),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								format := cmd.String("format")
+								templateText := cmd.String("template")
+								if templateText != "" && !cmd.IsSet("format") {
+									format = gotestguideapp.OutputFormatTemplate
+								}
+								return gotestguideapp.Query(client, querySourceFromFlags(cmd), format, templateText)
+							})
+						},
+					},
+					{
+						Name:  "export",
+						Usage: "Export the test case executions of a project filter or query as JUnit XML or HTML report",
+						Flags: append(querySourceFlags(),
+							&cli.StringFlag{
+								Name:     "format",
+								Usage:    "Report format (junit, html)",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Path of the report file, prints to stdout if not set",
+							},
+							&cli.StringFlag{
+								Name:  "title",
+								Usage: "Title of the HTML report",
+								Value: "Test Report",
+							},
+						),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								format := cmd.String("format")
+								output := cmd.String("output")
+								title := cmd.String("title")
+								return gotestguideapp.Export(client, querySourceFromFlags(cmd), format, output, title)
+							})
+						},
+					},
+					{
+						Name:  "diff",
+						Usage: "Compare the test case executions of two reports and print the regressions and fixes as Markdown",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "base",
+								Usage:    "ID of the base report, e.g. of the main branch",
+								Required: true,
+							},
+							&cli.Int64Flag{
+								Name:     "head",
+								Usage:    "ID of the report to compare with the base",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:    "output",
+								Aliases: []string{"o"},
+								Usage:   "Path of the Markdown file, prints to stdout if not set",
+							},
+							&cli.BoolFlag{
+								Name:  "fail-on-regression",
+								Usage: "Exit with an error if there are new failures",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								baseReportId := cmd.Int64("base")
+								headReportId := cmd.Int64("head")
+								output := cmd.String("output")
+								failOnRegression := cmd.Bool("fail-on-regression")
+								return gotestguideapp.DiffReports(client, baseReportId, headReportId, output, failOnRegression)
+							})
+						},
+					},
+					{
+						Name:  "flaky",
+						Usage: "Rank the flakiest tests of a project by their execution history",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "since",
+								Usage: "Time window of the execution history to analyze, e.g. 12h, 30d or 4w (a start date of the filter or query is used instead)",
+								Value: "30d",
+							},
+							&cli.IntFlag{
+								Name:  "min-executions",
+								Usage: "Minimum number of executions of a test to be ranked",
+								Value: 5,
+							},
+							&cli.IntFlag{
+								Name:  "top",
+								Usage: "Number of tests to show, 0 shows all flaky tests",
+								Value: 20,
+							},
+						}, filterSourceFlags("Query for the test case executions, e.g. 'suite:\"HIL*\"'")...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								// Without a filter, all tests of the project are analyzed
+								filter := &gotestguide.FilterParameters{}
+								if source := querySourceFromFlags(cmd); !source.IsEmpty() {
+									loadedFilter, err := gotestguideapp.LoadFilterParameters(client, source)
+									if err != nil {
+										return err
+									}
+									filter = loadedFilter
+								}
+								// A start date of the filter or query replaces the default time window
+								var window time.Duration
+								if filter.DateFrom == nil {
+									var err error
+									if window, err = gotestguide.ParseFilterDuration(cmd.String("since")); err != nil {
+										return err
+									}
+								} else if cmd.IsSet("since") {
+									return fmt.Errorf("--since cannot be combined with a filter or query which defines a start date")
+								}
+								minExecutions := cmd.Int("min-executions")
+								top := cmd.Int("top")
+								return gotestguideapp.Flaky(client, projectId, filter, window, minExecutions, top)
+							})
+						},
+					},
+					{
+						Name:  "stats",
+						Usage: "Print pass rates, verdict distributions and execution times of test case executions over time",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "interval",
+								Usage: "Interval of the time series (day, week)",
+								Value: "day",
+							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format (table, json, csv)",
+								Value: "table",
+							},
+						}, filterSourceFlags("Query for the test case executions, e.g. 'since:4w env:Bench=HIL1'")...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								// Without a filter, all test case executions of the project are used
+								filter := &gotestguide.FilterParameters{}
+								if source := querySourceFromFlags(cmd); !source.IsEmpty() {
+									loadedFilter, err := gotestguideapp.LoadFilterParameters(client, source)
+									if err != nil {
+										return err
+									}
+									filter = loadedFilter
+								}
+								return gotestguideapp.Stats(client, projectId, filter, cmd.String("interval"), cmd.String("format"))
+							})
+						},
+					},
+					{
+						Name:  "list-releases",
+						Usage: "List the releases of a project",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								return gotestguideapp.ListReleases(client, projectId)
+							})
+						},
+					},
+					{
+						Name:  "create-release",
+						Usage: "Create a new release in a project",
+						Flags: []cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "name",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name: "version",
+							},
+							&cli.StringFlag{
+								Name: "description",
+							},
+							&cli.StringFlag{
+								Name:  "due-date",
+								Usage: "Due date of the release (YYYY-MM-DD)",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								release := &gotestguide.Release{
+									Name:        cmd.String("name"),
+									Version:     cmd.String("version"),
+									Description: cmd.String("description"),
+								}
+								if dueDate := cmd.String("due-date"); dueDate != "" {
+									date, err := time.Parse(time.DateOnly, dueDate)
+									if err != nil {
+										return fmt.Errorf("invalid due date '%s', expected YYYY-MM-DD", dueDate)
+									}
+									release.DueDate = &date
+								}
+								return gotestguideapp.CreateRelease(client, projectId, release)
+							})
+						},
+					},
+					{
+						Name:  "close-release",
+						Usage: "Close a release",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "release",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								releaseId := cmd.Int64("release")
+								return gotestguideapp.CloseRelease(client, releaseId)
+							})
+						},
+					},
+					{
+						Name:  "assign-release",
+						Usage: "Assign test case executions or whole reports to a release",
+						Flags: releaseAssignmentFlags(),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								return gotestguideapp.AssignToRelease(client, cmd.Int64("release"), cmd.Int64Slice("tce"), cmd.Int64Slice("report"), false)
+							})
+						},
+					},
+					{
+						Name:  "unassign-release",
+						Usage: "Remove test case executions or whole reports from a release",
+						Flags: releaseAssignmentFlags(),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								return gotestguideapp.AssignToRelease(client, cmd.Int64("release"), cmd.Int64Slice("tce"), cmd.Int64Slice("report"), true)
+							})
+						},
+					},
+					{
+						Name:  "release-progress",
+						Usage: "Print the progress of a release",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "release",
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								releaseId := cmd.Int64("release")
+								return gotestguideapp.ReleaseProgress(client, releaseId)
+							})
+						},
+					},
+					{
+						Name:  "download-artifacts",
+						Usage: "Download all artifacts and review attachments of a test case execution, a report, a project filter or a query",
+						Flags: append(querySourceFlags(),
+							&cli.Int64Flag{
+								Name:  "tce",
+								Usage: "ID of the test case execution",
+							},
+							&cli.Int64Flag{
+								Name:  "report",
+								Usage: "ID of the report",
+							},
+							&cli.StringFlag{
+								Name:     "dir",
+								Usage:    "Directory to download the files to (<dir>/<report ID>/<test case execution ID>/...)",
+								Required: true,
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of files which are downloaded at the same time",
+								Value: 4,
+							},
+						),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								tceId := cmd.Int64("tce")
+								reportId := cmd.Int64("report")
+								dir := cmd.String("dir")
+								workers := cmd.Int("workers")
+								return gotestguideapp.DownloadArtifacts(client, tceId, reportId, querySourceFromFlags(cmd), dir, workers)
+							})
+						},
+					},
+					{
+						Name:  "export-report",
+						Usage: "Export a report with its original archive, test case executions and files into a portable bundle directory",
+						Flags: []cli.Flag{
+							&cli.Int64Flag{
+								Name:     "report",
+								Usage:    "ID of the report",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "dir",
+								Usage:    "Directory of the bundle",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "converter",
+								Usage: "Converter to store in the bundle for a later import",
+							},
+							&cli.BoolFlag{
+								Name:  "skip-artifacts",
+								Usage: "Do not download the artifacts and review attachments",
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of files which are downloaded at the same time",
+								Value: 4,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								reportId := cmd.Int64("report")
+								dir := cmd.String("dir")
+								converter := cmd.String("converter")
+								skipArtifacts := cmd.Bool("skip-artifacts")
+								workers := cmd.Int("workers")
+								return gotestguideapp.ExportReport(client, reportId, dir, converter, skipArtifacts, workers)
+							})
+						},
+					},
+					{
+						Name:  "import-report",
+						Usage: "Upload the original archive of an exported report bundle, e.g. to another instance",
 						Flags: []cli.Flag{
 							&cli.IntFlag{
 								Name:     "project",
@@ -45,68 +725,365 @@ func main() {
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "converter",
+								Name:     "dir",
+								Usage:    "Directory of the bundle",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "report",
-								Required: true,
+								Name:  "converter",
+								Usage: "Converter to use, defaults to the converter stored in the bundle",
+							},
+							&cli.BoolFlag{
+								Name:  "wait",
+								Usage: "Wait until the report is processed",
 							},
 						},
 						Action: func(ctx context.Context, cmd *cli.Command) error {
 							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
 								projectId := cmd.Int("project")
+								dir := cmd.String("dir")
 								converter := cmd.String("converter")
-								report := cmd.String("report")
-								return gotestguideapp.UploadReport(client, projectId, converter, report)
+								wait := cmd.Bool("wait")
+								return gotestguideapp.ImportReport(client, projectId, dir, converter, wait)
 							})
 						},
 					},
 					{
-						Name:  "delete-report",
-						Usage: "Delete the report with the given report ID",
+						Name:  "prune",
+						Usage: "Delete old reports of a project, keeping the latest, released or reviewed ones",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:     "older-than",
+								Usage:    "Only delete reports executed longer ago than this, e.g. 90d or 12w",
+								Required: true,
+							},
+							&cli.StringSliceFlag{
+								Name:  "status",
+								Usage: "Only delete reports with this status, e.g. COMPLETE_WITH_ERROR",
+							},
+							&cli.StringFlag{
+								Name:  "test-plan",
+								Usage: "Only delete reports whose test plan name matches this pattern, e.g. 'Nightly*'",
+							},
+							&cli.IntFlag{
+								Name:  "keep-last",
+								Usage: "Keep the latest N reports of each test plan",
+							},
+							&cli.BoolFlag{
+								Name:  "keep-released",
+								Usage: "Keep reports with test case executions assigned to a release",
+							},
+							&cli.BoolFlag{
+								Name:  "keep-reviewed",
+								Usage: "Keep reports with reviewed test case executions",
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of reports which are deleted at the same time",
+								Value: 4,
+							},
+							&cli.DurationFlag{
+								Name:  "timeout",
+								Usage: "Maximum time to wait for the deletion of each report, 0 waits forever",
+								Value: 5 * time.Minute,
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Only show which reports would be deleted",
+							},
+						}, filterSourceFlags("Only delete reports with test case executions matching this query")...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								olderThan, err := gotestguide.ParseFilterDuration(cmd.String("older-than"))
+								if err != nil {
+									return err
+								}
+								options := &gotestguide.PruneOptions{
+									OlderThan:       olderThan,
+									TestPlanPattern: cmd.String("test-plan"),
+									KeepLast:        cmd.Int("keep-last"),
+									KeepReleased:    cmd.Bool("keep-released"),
+									KeepReviewed:    cmd.Bool("keep-reviewed"),
+									Workers:         cmd.Int("workers"),
+									Timeout:         cmd.Duration("timeout"),
+									DryRun:          cmd.Bool("dry-run"),
+								}
+								for _, status := range cmd.StringSlice("status") {
+									options.Statuses = append(options.Statuses, gotestguide.ReportStatus(strings.ToUpper(status)))
+								}
+								if source := querySourceFromFlags(cmd); !source.IsEmpty() {
+									filter, err := gotestguideapp.LoadFilterParameters(client, source)
+									if err != nil {
+										return err
+									}
+									options.Filter = filter
+								}
+								return gotestguideapp.Prune(client, projectId, options)
+							})
+						},
+					},
+					{
+						Name:  "show-tce",
+						Usage: "Show a test case execution with its test step tree",
 						Flags: []cli.Flag{
 							&cli.Int64Flag{
-								Name:     "report",
-								Aliases:  []string{"reportId"},
+								Name:     "tce",
+								Usage:    "ID of the test case execution",
 								Required: true,
 							},
+							&cli.StringFlag{
+								Name:  "format",
+								Usage: "Output format: text or markdown",
+								Value: gotestguideapp.ShowFormatText,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								tceId := cmd.Int64("tce")
+								format := cmd.String("format")
+								return gotestguideapp.ShowTestCaseExecution(client, tceId, format)
+							})
+						},
+					},
+					{
+						Name:  "set-attributes",
+						Usage: "Set or remove attributes of test case executions, selected by ID or by filter",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:    "project",
+								Aliases: []string{"projectId"},
+								Usage:   "ID of the project, needed for filter names, filter files and queries",
+							},
+							&cli.Int64SliceFlag{
+								Name:  "tce",
+								Usage: "ID of a test case execution, can be repeated",
+							},
+							&cli.StringSliceFlag{
+								Name:  "attribute",
+								Usage: "Attribute to set as KEY=VALUE, can be repeated (repeated keys get multiple values)",
+							},
+							&cli.StringSliceFlag{
+								Name:  "remove",
+								Usage: "Key of an attribute to remove, can be repeated",
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of test case executions which are updated at the same time",
+								Value: 4,
+							},
+							&cli.BoolFlag{
+								Name:  "dry-run",
+								Usage: "Only list the test case executions which would be updated",
+							},
+						}, filterSourceFlags("Query for the test case executions, e.g. 'report:1234'")...),
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								tceIds := cmd.Int64Slice("tce")
+								source := querySourceFromFlags(cmd)
+								if len(tceIds) > 0 && !source.IsEmpty() {
+									return fmt.Errorf("--tce cannot be combined with a filter or query")
+								}
+								var filter *gotestguide.FilterParameters
+								if len(tceIds) == 0 {
+									loadedFilter, err := gotestguideapp.LoadFilterParameters(client, source)
+									if err != nil {
+										return err
+									}
+									filter = loadedFilter
+								}
+								attributes, err := gotestguideapp.ParseAttributes(cmd.StringSlice("attribute"))
+								if err != nil {
+									return err
+								}
+								update := &gotestguide.AttributeUpdate{Set: attributes, Remove: cmd.StringSlice("remove")}
+								workers := cmd.Int("workers")
+								dryRun := cmd.Bool("dry-run")
+								return gotestguideapp.UpdateAttributes(client, projectId, tceIds, filter, update, workers, dryRun)
+							})
 						},
+					},
+					{
+						Name:  "add-artifacts",
+						Usage: "Add files or directories to all test case executions of a report, filter or query",
+						Flags: append([]cli.Flag{
+							&cli.IntFlag{
+								Name:    "project",
+								Aliases: []string{"projectId"},
+								Usage:   "ID of the project, needed for filter names, filter files and queries",
+							},
+							&cli.Int64SliceFlag{
+								Name:  "tce",
+								Usage: "ID of a test case execution, can be repeated",
+							},
+							&cli.Int64Flag{
+								Name:  "report",
+								Usage: "ID of a report whose test case executions get the artifacts",
+							},
+							&cli.StringSliceFlag{
+								Name:     "artifact",
+								Usage:    "Path to a file or directory to add, can be repeated. Files of directories are named by their relative path, e.g. 'plots/speed.png'",
+								Required: true,
+							},
+							&cli.StringFlag{
+								Name:  "comment",
+								Usage: "Optional comment of the artifacts",
+							},
+							&cli.StringFlag{
+								Name:  "category",
+								Usage: "Optional category of the artifacts",
+							},
+							&cli.IntFlag{
+								Name:  "workers",
+								Usage: "Number of test case executions which are processed at the same time",
+								Value: 4,
+							},
+							&cli.BoolFlag{
+								Name:  "keep-duplicates",
+								Usage: "Also add files which are already attached or contained multiple times",
+							},
+						}, filterSourceFlags("Query for the test case executions, e.g. 'verdict:FAILED since:1d'")...),
 						Action: func(ctx context.Context, cmd *cli.Command) error {
 							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								projectId := cmd.Int("project")
+								tceIds := cmd.Int64Slice("tce")
 								reportId := cmd.Int64("report")
-								return gotestguideapp.DeleteReport(client, reportId)
+								source := querySourceFromFlags(cmd)
+								if (len(tceIds) > 0 && reportId != 0) || ((len(tceIds) > 0 || reportId != 0) && !source.IsEmpty()) {
+									return fmt.Errorf("only one of --tce, --report and a filter or query can be used")
+								}
+								var filter *gotestguide.FilterParameters
+								if len(tceIds) == 0 && reportId == 0 {
+									loadedFilter, err := gotestguideapp.LoadFilterParameters(client, source)
+									if err != nil {
+										return err
+									}
+									filter = loadedFilter
+								}
+								paths := cmd.StringSlice("artifact")
+								options := &gotestguide.AddArtifactsOptions{
+									Comment:        cmd.String("comment"),
+									Category:       cmd.String("category"),
+									Workers:        cmd.Int("workers"),
+									KeepDuplicates: cmd.Bool("keep-duplicates"),
+								}
+								return gotestguideapp.AddArtifacts(client, projectId, tceIds, reportId, filter, paths, options)
 							})
 						},
 					},
 					{
-						Name:  "add-artifact",
-						Usage: "Add a new artifact",
+						Name:  "watch",
+						Usage: "Watch a directory and upload new report files automatically until interrupted",
 						Flags: []cli.Flag{
-							&cli.Int64Flag{
-								Name:     "tce",
+							&cli.IntFlag{
+								Name:     "project",
+								Aliases:  []string{"projectId"},
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "artifact",
+								Name:     "converter",
 								Required: true,
 							},
 							&cli.StringFlag{
-								Name:     "comment",
+								Name:     "dir",
+								Usage:    "Directory where the report files are dropped",
 								Required: true,
 							},
+							&cli.StringSliceFlag{
+								Name:  "pattern",
+								Usage: "Glob pattern of the report file names, e.g. '*.xml', can be repeated",
+							},
+							&cli.StringFlag{
+								Name:  "done-dir",
+								Usage: "Directory for uploaded reports (default: <dir>/done)",
+							},
+							&cli.StringFlag{
+								Name:  "failed-dir",
+								Usage: "Directory for reports which failed to upload (default: <dir>/failed)",
+							},
+							&cli.StringFlag{
+								Name:  "state-file",
+								Usage: "File which stores the running uploads to continue after a restart (default: <dir>/.go-test-guide-watch.json)",
+							},
+							&cli.DurationFlag{
+								Name:  "stable-time",
+								Usage: "Time a file must be unchanged before it is uploaded",
+								Value: 5 * time.Second,
+							},
+							&cli.DurationFlag{
+								Name:  "poll-interval",
+								Usage: "Interval to scan the directory",
+								Value: 10 * time.Second,
+							},
+							&cli.DurationFlag{
+								Name:  "timeout",
+								Usage: "Maximum time to wait for each upload to be processed (0 = no timeout)",
+							},
+							&cli.BoolFlag{
+								Name:  "polling",
+								Usage: "Only poll the directory instead of using file system notifications",
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								options := &gotestguide.ReportWatcherOptions{
+									ProjectID:     cmd.Int("project"),
+									ConverterID:   cmd.String("converter"),
+									Dir:           cmd.String("dir"),
+									Patterns:      cmd.StringSlice("pattern"),
+									DoneDir:       cmd.String("done-dir"),
+									FailedDir:     cmd.String("failed-dir"),
+									StateFile:     cmd.String("state-file"),
+									StableTime:    cmd.Duration("stable-time"),
+									PollInterval:  cmd.Duration("poll-interval"),
+									UploadTimeout: cmd.Duration("timeout"),
+									ForcePolling:  cmd.Bool("polling"),
+								}
+								return gotestguideapp.Watch(ctx, client, options)
+							})
+						},
+					},
+					{
+						Name:  "spool-list",
+						Usage: "List the uploads which are queued in an upload spool",
+						Flags: []cli.Flag{
 							&cli.StringFlag{
-								Name:     "category",
+								Name:     "spool-dir",
+								Usage:    "Directory of the upload spool",
+								Sources:  cli.EnvVars("TEST_GUIDE_SPOOL_DIR"),
 								Required: true,
 							},
+							&cli.BoolFlag{
+								Name:  "failed",
+								Usage: "List the entries which were rejected by the server instead",
+							},
 						},
 						Action: func(ctx context.Context, cmd *cli.Command) error {
 							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
-								tceId := cmd.Int64("tce")
-								filePath := cmd.String("artifact")
-								comment := cmd.String("comment")
-								category := cmd.String("category")
-								return gotestguideapp.AddArtifact(client, tceId, filePath, comment, category)
+								return gotestguideapp.ListSpool(client, cmd.String("spool-dir"), cmd.Bool("failed"))
+							})
+						},
+					},
+					{
+						Name:  "spool-flush",
+						Usage: "Replay the uploads which are queued in an upload spool in order",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:     "spool-dir",
+								Usage:    "Directory of the upload spool",
+								Sources:  cli.EnvVars("TEST_GUIDE_SPOOL_DIR"),
+								Required: true,
+							},
+						},
+						Action: func(ctx context.Context, cmd *cli.Command) error {
+							return runAction(ctx, cmd, func(client *gotestguide.Client) error {
+								return gotestguideapp.FlushSpool(client, cmd.String("spool-dir"))
 							})
 						},
 					},
@@ -128,6 +1105,169 @@ func runAction(ctx context.Context, cmd *cli.Command, clientFunc func(client *go
 	return clientFunc(client)
 }
 
+// Flags to assign or unassign objects to or from a release.
+func releaseAssignmentFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.Int64Flag{
+			Name:     "release",
+			Required: true,
+		},
+		&cli.Int64SliceFlag{
+			Name:  "tce",
+			Usage: "ID of a test case execution, can be used multiple times",
+		},
+		&cli.Int64SliceFlag{
+			Name:  "report",
+			Usage: "ID of a report whose test case executions are used, can be used multiple times",
+		},
+	}
+}
+
+// Flags to define the source of test case executions.
+func querySourceFlags() []cli.Flag {
+	return append([]cli.Flag{
+		&cli.IntFlag{
+			Name:    "project",
+			Aliases: []string{"projectId"},
+			Usage:   "ID of the project, needed for filter names, filter files and queries",
+		},
+	}, filterSourceFlags("Query for the test case executions, e.g. 'verdict:FAILED,ERROR suite:\"Brake*\" since:7d'")...)
+}
+
+// Flags to select the filter for commands which already have a project flag.
+func filterSourceFlags(queryUsage string) []cli.Flag {
+	return []cli.Flag{
+		&cli.Int64Flag{
+			Name:  "filter-id",
+			Usage: "ID of the project filter to use",
+		},
+		&cli.StringFlag{
+			Name:  "filter-name",
+			Usage: "Name of the project filter to use",
+		},
+		&cli.StringFlag{
+			Name:  "filter-file",
+			Usage: "Path to a JSON file with the filter parameters",
+		},
+		&cli.StringFlag{
+			Name:  "query",
+			Usage: queryUsage,
+		},
+	}
+}
+
+// Creates the query source from the query source or filter source flags.
+func querySourceFromFlags(cmd *cli.Command) *gotestguideapp.QuerySource {
+	return &gotestguideapp.QuerySource{
+		ProjectId:  cmd.Int("project"),
+		FilterId:   cmd.Int64("filter-id"),
+		FilterName: cmd.String("filter-name"),
+		FilterFile: cmd.String("filter-file"),
+		Query:      cmd.String("query"),
+	}
+}
+
+// Flags to add the CI and git metadata to uploaded reports.
+func buildMetadataFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "ci-metadata",
+			Usage: "Add the detected CI build and git metadata as constants to all test cases (json2atx reports only)",
+		},
+		&cli.BoolFlag{
+			Name:  "ci-metadata-attributes",
+			Usage: "Also add the CI build and git metadata as attributes, needs --ci-metadata",
+		},
+	}
+}
+
+// Flags to derive idempotent report identifiers.
+func identifierFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "derive-identifier",
+			Usage: "Derive the report identifier from the report content and the pipeline ID, so that retries are detected as double uploads (json2atx reports only)",
+		},
+		&cli.StringFlag{
+			Name:  "pipeline-id",
+			Usage: "Pipeline ID used to derive the report identifier (default: detected CI pipeline ID), needs --derive-identifier",
+		},
+	}
+}
+
+// Collects the changes to json2atx reports from the metadata and identifier flags.
+func reportPreparationFromFlags(cmd *cli.Command) (*gotestguideapp.ReportPreparation, error) {
+	preparation := &gotestguideapp.ReportPreparation{
+		AddMetadata:          cmd.Bool("ci-metadata"),
+		MetadataAsAttributes: cmd.Bool("ci-metadata-attributes"),
+		DeriveIdentifier:     cmd.Bool("derive-identifier"),
+		PipelineID:           cmd.String("pipeline-id"),
+	}
+	if preparation.MetadataAsAttributes && !preparation.AddMetadata {
+		return nil, fmt.Errorf("--ci-metadata-attributes needs --ci-metadata")
+	}
+	if preparation.PipelineID != "" && !preparation.DeriveIdentifier {
+		return nil, fmt.Errorf("--pipeline-id needs --derive-identifier")
+	}
+	return preparation, nil
+}
+
+// Flags to define the content of a review.
+func reviewFlags() []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:     "comment",
+			Required: true,
+		},
+		&cli.StringFlag{
+			Name: "summary",
+		},
+		&cli.StringFlag{
+			Name:  "verdict",
+			Usage: "Verdict override (PASSED, INCONCLUSIVE, FAILED, ERROR, NONE)",
+		},
+		&cli.StringFlag{
+			Name: "defect-class",
+		},
+		&cli.StringFlag{
+			Name: "defect-priority",
+		},
+		&cli.StringSliceFlag{
+			Name:  "ticket",
+			Usage: "Ticket to link, can be repeated",
+		},
+		&cli.StringSliceFlag{
+			Name:  "tag",
+			Usage: "Tag to add, can be repeated",
+		},
+		&cli.BoolFlag{
+			Name:  "invalid-run",
+			Usage: "Mark the test case execution as invalid run",
+		},
+	}
+}
+
+// Creates a review from the review flags.
+func reviewFromFlags(cmd *cli.Command) (*gotestguide.Review, error) {
+	var verdict gotestguide.Verdict
+	if cmd.String("verdict") != "" {
+		var err error
+		if verdict, err = gotestguide.ParseVerdict(cmd.String("verdict")); err != nil {
+			return nil, err
+		}
+	}
+	return &gotestguide.Review{
+		Comment:        cmd.String("comment"),
+		Summary:        cmd.String("summary"),
+		Verdict:        verdict,
+		DefectClass:    cmd.String("defect-class"),
+		DefectPriority: cmd.String("defect-priority"),
+		Tickets:        cmd.StringSlice("ticket"),
+		Tags:           cmd.StringSlice("tag"),
+		InvalidRun:     cmd.Bool("invalid-run"),
+	}, nil
+}
+
 func createClient(cmd *cli.Command) (*gotestguide.Client, error) {
 	baseURL := cmd.String("base-url")
 	token := cmd.String("token")
@@ -136,5 +1276,37 @@ func createClient(cmd *cli.Command) (*gotestguide.Client, error) {
 		return nil, fmt.Errorf("base-url and token are required")
 	}
 
-	return gotestguide.NewClient(baseURL, token)
+	options := []gotestguide.ClientOption{}
+	if caCert := cmd.String("ca-cert"); caCert != "" {
+		options = append(options, gotestguide.WithCACertFile(caCert))
+	}
+	clientCert := cmd.String("client-cert")
+	clientKey := cmd.String("client-key")
+	if clientCert != "" || clientKey != "" {
+		if clientCert == "" || clientKey == "" {
+			return nil, fmt.Errorf("client-cert and client-key must be used together")
+		}
+		options = append(options, gotestguide.WithClientCertificate(clientCert, clientKey))
+	}
+	// The hosts without proxy also apply to the proxy from the environment
+	if proxy, noProxy := cmd.String("proxy"), cmd.String("no-proxy"); proxy != "" || noProxy != "" {
+		options = append(options, gotestguide.WithProxy(proxy, noProxy))
+	}
+	if cmd.Bool("insecure") {
+		options = append(options, gotestguide.WithInsecureSkipVerify())
+	}
+	if rate, concurrency := cmd.Float("upload-rate"), cmd.Int("upload-concurrency"); rate > 0 || concurrency > 0 {
+		options = append(options, gotestguide.WithUploadLimits(gotestguide.RequestLimits{
+			RequestsPerSecond: rate,
+			MaxInFlight:       concurrency,
+		}))
+	}
+	if rate, concurrency := cmd.Float("query-rate"), cmd.Int("query-concurrency"); rate > 0 || concurrency > 0 {
+		options = append(options, gotestguide.WithQueryLimits(gotestguide.RequestLimits{
+			RequestsPerSecond: rate,
+			MaxInFlight:       concurrency,
+		}))
+	}
+
+	return gotestguide.NewClient(baseURL, token, options...)
 }
@@ -0,0 +1,139 @@
+package gotestguide
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// This file contains the export of test case executions into a self-contained HTML report.
+////////////////////////////////////////////////////////////
+
+type htmlReportData struct {
+	Title     string
+	Generated time.Time
+	Total     int
+	Verdicts  []*htmlVerdictCount
+	Suites    []*htmlSuite
+}
+
+type htmlVerdictCount struct {
+	Verdict Verdict
+	Count   int
+	Percent float64
+}
+
+type htmlSuite struct {
+	Name     string
+	Verdicts []*htmlVerdictCount
+	Tces     []*TestCaseExecution
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"verdictClass": func(verdict any) string { return strings.ToLower(fmt.Sprint(verdict)) },
+	"formatTime":   func(t time.Time) string { return t.Format("2006-01-02 15:04:05") },
+	"join":         joinValues,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #f0f0f0; }
+.bar { display: flex; height: 24px; margin: 1em 0; border: 1px solid #ccc; }
+.bar div { height: 100%; }
+.verdict { font-weight: bold; padding: 1px 6px; border-radius: 3px; color: #fff; }
+.passed { background: #3a9d23; }
+.failed { background: #d9302c; }
+.error { background: #8b1a9c; }
+.inconclusive { background: #e8a317; }
+.none { background: #888; }
+details { margin: 2px 0; }
+ul.steps { margin: 2px 0; padding-left: 1.5em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p>Generated {{formatTime .Generated}} &middot; {{.Total}} test case executions</p>
+<div class="bar">{{range .Verdicts}}<div class="{{verdictClass .Verdict}}" style="width: {{printf "%.2f" .Percent}}%" title="{{.Verdict}}: {{.Count}}"></div>{{end}}</div>
+<table>
+<tr><th>Verdict</th><th>Count</th><th>Percent</th></tr>
+{{range .Verdicts}}<tr><td><span class="verdict {{verdictClass .Verdict}}">{{.Verdict}}</span></td><td>{{.Count}}</td><td>{{printf "%.1f" .Percent}}%</td></tr>
+{{end}}</table>
+{{range .Suites}}
+<h2>{{if .Name}}{{.Name}}{{else}}(no test suite){{end}}</h2>
+<p>{{range .Verdicts}}<span class="verdict {{verdictClass .Verdict}}">{{.Verdict}}: {{.Count}}</span> {{end}}</p>
+<table>
+<tr><th>Test Case</th><th>Verdict</th><th>Executed</th><th>Time</th><th>Details</th></tr>
+{{range .Tces}}<tr>
+<td>{{.TestCaseName}}{{if .ParameterSet}} [{{.ParameterSet}}]{{end}}</td>
+<td><span class="verdict {{verdictClass .GetEffectiveVerdict}}">{{.GetEffectiveVerdict}}</span>{{if ne .GetEffectiveVerdict .Verdict}} (original: {{.Verdict}}){{end}}</td>
+<td>{{formatTime .ExecutionTimestamp}}</td>
+<td>{{.ExecutionTime}}s</td>
+<td>
+{{with .LastReview}}<details><summary>Review</summary>{{.Reviewer}}: {{.Comment}}{{if .Tickets}}<br>Tickets: {{join "" .Tickets}}{{end}}{{if .DefectClass}}<br>Defect: {{.DefectClass}}{{end}}</details>{{end}}
+{{if or .Constants .Attributes .TestEnvironments}}<details><summary>Properties</summary><ul>
+{{range .TestEnvironments}}<li>Environment {{.Key}} = {{.Value}}</li>{{end}}
+{{range .Constants}}<li>Constant {{.Key}} = {{join .Value .Values}}</li>{{end}}
+{{range .Attributes}}<li>Attribute {{.Key}} = {{join .Value .Values}}</li>{{end}}
+</ul></details>{{end}}
+{{if .Artifacts}}<details><summary>Artifacts</summary><ul>{{range .Artifacts}}<li><a href="{{.DownloadURL}}">{{.Filename}}</a></li>{{end}}</ul></details>{{end}}
+{{with .TestSteps}}<details><summary>Test Steps</summary>
+{{if .Setup}}<b>Setup</b>{{template "steps" .Setup}}{{end}}
+{{if .Execution}}<b>Execution</b>{{template "steps" .Execution}}{{end}}
+{{if .Teardown}}<b>Teardown</b>{{template "steps" .Teardown}}{{end}}
+</details>{{end}}
+</td>
+</tr>
+{{end}}</table>
+{{end}}
+</body>
+</html>
+{{define "steps"}}<ul class="steps">{{range .}}{{with .AsTestStepFolder}}<li>{{.Name}} <span class="verdict {{verdictClass .Verdict}}">{{.Verdict}}</span>{{template "steps" .TestSteps}}</li>{{end}}{{with .AsTestStep}}<li>{{.Name}}{{if .Verdict}} <span class="verdict {{verdictClass .Verdict}}">{{.Verdict}}</span>{{end}}</li>{{end}}{{end}}</ul>{{end}}
+`))
+
+// Writes the test case executions as self-contained HTML report with verdict breakdowns per test suite.
+func WriteHTMLReport(w io.Writer, tces []*TestCaseExecution, title string) error {
+	data := &htmlReportData{
+		Title:     title,
+		Generated: time.Now(),
+		Total:     len(tces),
+		Verdicts:  toHTMLVerdictCounts(tces),
+	}
+	suitesByName := map[string]*htmlSuite{}
+	for _, tce := range tces {
+		suite, ok := suitesByName[tce.TestSuiteName]
+		if !ok {
+			suite = &htmlSuite{Name: tce.TestSuiteName}
+			suitesByName[tce.TestSuiteName] = suite
+			data.Suites = append(data.Suites, suite)
+		}
+		suite.Tces = append(suite.Tces, tce)
+	}
+	for _, suite := range data.Suites {
+		suite.Verdicts = toHTMLVerdictCounts(suite.Tces)
+	}
+	if err := htmlReportTemplate.Execute(w, data); err != nil {
+		return fmt.Errorf("failed to write HTML report: %w", err)
+	}
+	return nil
+}
+
+func toHTMLVerdictCounts(tces []*TestCaseExecution) []*htmlVerdictCount {
+	counts := CountVerdicts(tces)
+	ret := []*htmlVerdictCount{}
+	for _, verdict := range sortedVerdicts(counts) {
+		ret = append(ret, &htmlVerdictCount{
+			Verdict: verdict,
+			Count:   counts[verdict],
+			Percent: float64(counts[verdict]) * 100 / float64(len(tces)),
+		})
+	}
+	return ret
+}
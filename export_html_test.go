@@ -0,0 +1,28 @@
+package gotestguide
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExport_HTML(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	buf := &bytes.Buffer{}
+
+	// Execute
+	err := WriteHTMLReport(buf, getExportTestCaseExecutions(), "Release 1.0")
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	html := buf.String()
+	assert.Contains(html, "<title>Release 1.0</title>")
+	assert.Contains(html, "<h2>Steering</h2>")
+	assert.Contains(html, "(original: FAILED)")
+	assert.Contains(html, "Sensor &lt;broken&gt;", "Should escape the content")
+	assert.Contains(html, `<a href="https://tg/file/1">trace.mf4</a>`)
+	assert.Contains(html, "Power on")
+	assert.Contains(html, "FAILED: 1")
+}
@@ -0,0 +1,186 @@
+package gotestguide
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// This file contains the export of test case executions into the JUnit XML format.
+////////////////////////////////////////////////////////////
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Tests     int              `xml:"tests,attr"`
+	Failures  int              `xml:"failures,attr"`
+	Errors    int              `xml:"errors,attr"`
+	Skipped   int              `xml:"skipped,attr"`
+	Time      string           `xml:"time,attr"`
+	Timestamp string           `xml:"timestamp,attr,omitempty"`
+	TestCases []*junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name       string           `xml:"name,attr"`
+	ClassName  string           `xml:"classname,attr"`
+	Time       string           `xml:"time,attr"`
+	Properties *junitProperties `xml:"properties,omitempty"`
+	Failure    *junitResult     `xml:"failure,omitempty"`
+	Error      *junitResult     `xml:"error,omitempty"`
+	Skipped    *junitResult     `xml:"skipped,omitempty"`
+	SystemOut  *junitText       `xml:"system-out,omitempty"`
+}
+
+type junitText struct {
+	Text string `xml:",cdata"`
+}
+
+type junitProperties struct {
+	Properties []*junitProperty `xml:"property"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitResult struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// Writes the test case executions as JUnit XML report.
+// The test case executions are grouped into test suites by their test suite name.
+// The effective verdict (including reviews) is used for the result of each test case.
+func WriteJUnitReport(w io.Writer, tces []*TestCaseExecution) error {
+	suites := &junitTestSuites{}
+	suitesByName := map[string]*junitTestSuite{}
+	suiteTimes := map[string]float64{}
+	totalTime := 0.0
+	for _, tce := range tces {
+		suite, ok := suitesByName[tce.TestSuiteName]
+		if !ok {
+			suite = &junitTestSuite{Name: tce.TestSuiteName}
+			if !tce.ExecutionTimestamp.IsZero() {
+				suite.Timestamp = tce.ExecutionTimestamp.Format(time.RFC3339)
+			}
+			suitesByName[tce.TestSuiteName] = suite
+			suites.Suites = append(suites.Suites, suite)
+		}
+		testCase := toJUnitTestCase(tce)
+		suite.TestCases = append(suite.TestCases, testCase)
+		suite.Tests++
+		suiteTimes[tce.TestSuiteName] += float64(tce.ExecutionTime)
+		totalTime += float64(tce.ExecutionTime)
+		switch {
+		case testCase.Failure != nil:
+			suite.Failures++
+		case testCase.Error != nil:
+			suite.Errors++
+		case testCase.Skipped != nil:
+			suite.Skipped++
+		}
+	}
+	for _, suite := range suites.Suites {
+		suite.Time = formatJUnitTime(suiteTimes[suite.Name])
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+	}
+	suites.Time = formatJUnitTime(totalTime)
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func toJUnitTestCase(tce *TestCaseExecution) *junitTestCase {
+	name := tce.TestCaseName
+	if tce.ParameterSet != "" {
+		name = fmt.Sprintf("%s [%s]", tce.TestCaseName, tce.ParameterSet)
+	}
+	testCase := &junitTestCase{
+		Name:      name,
+		ClassName: tce.TestSuiteName,
+		Time:      formatJUnitTime(float64(tce.ExecutionTime)),
+	}
+	if systemOut := junitSystemOut(tce); systemOut != "" {
+		testCase.SystemOut = &junitText{Text: systemOut}
+	}
+
+	// Add the properties
+	properties := []*junitProperty{
+		{Name: "testguide.id", Value: fmt.Sprint(tce.ID)},
+		{Name: "testguide.reportId", Value: fmt.Sprint(tce.ReportID)},
+		{Name: "testguide.verdict", Value: string(tce.Verdict)},
+	}
+	for _, env := range tce.TestEnvironments {
+		properties = append(properties, &junitProperty{Name: "environment." + env.Key, Value: env.Value})
+	}
+	for _, constant := range tce.Constants {
+		properties = append(properties, &junitProperty{Name: "constant." + constant.Key, Value: joinValues(constant.Value, constant.Values)})
+	}
+	for _, attribute := range tce.Attributes {
+		properties = append(properties, &junitProperty{Name: "attribute." + attribute.Key, Value: joinValues(attribute.Value, attribute.Values)})
+	}
+	testCase.Properties = &junitProperties{Properties: properties}
+
+	// Set the result
+	message := ""
+	if tce.LastReview != nil {
+		message = tce.LastReview.Comment
+	}
+	switch tce.GetEffectiveVerdict() {
+	case VERDICT_FAILED:
+		testCase.Failure = &junitResult{Message: message, Type: string(VERDICT_FAILED)}
+	case VERDICT_ERROR:
+		testCase.Error = &junitResult{Message: message, Type: string(VERDICT_ERROR)}
+	case VERDICT_INCONCLUSIVE, VERDICT_NONE:
+		testCase.Skipped = &junitResult{Message: string(tce.GetEffectiveVerdict())}
+	}
+	return testCase
+}
+
+// Creates the system output with the review, the artifacts and the test steps.
+func junitSystemOut(tce *TestCaseExecution) string {
+	var sb strings.Builder
+	if review := tce.LastReview; review != nil {
+		fmt.Fprintf(&sb, "Review by %s (%s): %s\n", review.Reviewer, review.ReviewDate.Format(time.RFC3339), review.Comment)
+		if len(review.Tickets) > 0 {
+			fmt.Fprintf(&sb, "Tickets: %s\n", strings.Join(review.Tickets, ", "))
+		}
+	}
+	for _, artifact := range tce.Artifacts {
+		fmt.Fprintf(&sb, "[[ATTACHMENT|%s]]\n", artifact.DownloadURL)
+	}
+	if tce.TestSteps != nil {
+		sb.WriteString(tce.TestSteps.Text())
+	}
+	return sb.String()
+}
+
+func formatJUnitTime(seconds float64) string {
+	return fmt.Sprintf("%.3f", seconds)
+}
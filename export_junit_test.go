@@ -0,0 +1,61 @@
+package gotestguide
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates some test case executions for the export tests.
+func getExportTestCaseExecutions() []*TestCaseExecution {
+	timestamp := time.Date(2025, 8, 7, 15, 0, 0, 0, time.UTC)
+	return []*TestCaseExecution{
+		{
+			ID: 1, ReportID: 10, TestSuiteName: "Brake", TestCaseName: "Emergency Stop", ParameterSet: "wet",
+			ExecutionTimestamp: timestamp, Verdict: VERDICT_PASSED, EffectiveVerdict: VERDICT_PASSED, ExecutionTime: 12,
+			Constants: []*Constant{{Key: "SIL", Value: "1"}},
+			TestSteps: &TestSteps{Execution: []IAbstractTestStep{
+				&TestStepFolder{Name: "Prepare", Verdict: VERDICT_PASSED, TestSteps: []IAbstractTestStep{&TestStep{Name: "Power on", Verdict: "PASSED"}}},
+			}},
+		},
+		{
+			ID: 2, ReportID: 10, TestSuiteName: "Brake", TestCaseName: "ABS", ExecutionTimestamp: timestamp,
+			Verdict: VERDICT_FAILED, EffectiveVerdict: VERDICT_FAILED, ExecutionTime: 3,
+			LastReview: &Review{Comment: "Sensor <broken>", Reviewer: "jdoe", Tickets: []string{"PROJ-1"}},
+			Artifacts:  []*FileReference{{Filename: "trace.mf4", DownloadURL: "https://tg/file/1"}},
+		},
+		{
+			ID: 3, ReportID: 10, TestSuiteName: "Steering", TestCaseName: "Lane Keeping", ExecutionTimestamp: timestamp,
+			Verdict: VERDICT_FAILED, EffectiveVerdict: VERDICT_INCONCLUSIVE, ExecutionTime: 5,
+		},
+	}
+}
+
+func TestExport_JUnit(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	buf := &bytes.Buffer{}
+
+	// Execute
+	err := WriteJUnitReport(buf, getExportTestCaseExecutions())
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	var suites junitTestSuites
+	assert.NoError(xml.Unmarshal(buf.Bytes(), &suites), "Should be valid XML")
+	assert.Equal(3, suites.Tests)
+	assert.Equal(1, suites.Failures)
+	assert.Equal(1, suites.Skipped)
+	assert.Equal("20.000", suites.Time)
+	assert.Len(suites.Suites, 2, "Should group by test suite")
+	brake := suites.Suites[0]
+	assert.Equal("Brake", brake.Name)
+	assert.Equal("Emergency Stop [wet]", brake.TestCases[0].Name)
+	assert.Contains(brake.TestCases[0].SystemOut.Text, "    Power on [PASSED]")
+	assert.Equal("Sensor <broken>", brake.TestCases[1].Failure.Message)
+	assert.Contains(brake.TestCases[1].SystemOut.Text, "[[ATTACHMENT|https://tg/file/1]]")
+	assert.Contains(brake.TestCases[0].Properties.Properties, &junitProperty{Name: "constant.SIL", Value: "1"})
+}
@@ -0,0 +1,455 @@
+package gotestguide
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+////////////////////////////////////////////////////////////
+// This file contains a small query language which is compiled into FilterParameters.
+//
+// A query consists of whitespace separated terms in the form key:value.
+// Multiple values are separated by commas, values with spaces or commas can be quoted.
+// Attributes and constants use key:name=value and can be negated with a leading "!".
+// Repeating a key adds the values to the same filter field.
+//
+// Example: verdict:FAILED,ERROR suite:"Brake*" attr:ECU=v2 !const:SIL=1 since:7d review:none
+////////////////////////////////////////////////////////////
+
+// An error of a query which could not be parsed.
+type FilterQueryError struct {
+	Query string
+	// Position (zero-based byte offset) of the error in the query.
+	Position int
+	Message  string
+}
+
+// The message reports the one-based position in characters, so that the caret matches queries with non-ASCII characters.
+func (e *FilterQueryError) Error() string {
+	column := utf8.RuneCountInString(e.Query[:min(e.Position, len(e.Query))])
+	return fmt.Sprintf("invalid query at position %d: %s\n  %s\n  %s^", column+1, e.Message, e.Query, strings.Repeat(" ", column))
+}
+
+// A single key:value term of a query.
+type filterQueryTerm struct {
+	position      int
+	valuePosition int
+	negated       bool
+	key           string
+	values        []string
+}
+
+// The handlers for all supported keys.
+var filterQueryKeys = map[string]func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error{
+	"verdict": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		for _, value := range term.values {
+			verdict, err := ParseVerdict(value)
+			if err != nil {
+				return err
+			}
+			filter.Verdicts = append(filter.Verdicts, verdict)
+		}
+		return nil
+	},
+	"suite": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.TestSuiteName = append(filter.TestSuiteName, term.values...)
+		return nil
+	},
+	"test": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.TestCaseName = append(filter.TestCaseName, term.values...)
+		return nil
+	},
+	"paramset": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.ParameterSetName = append(filter.ParameterSetName, term.values...)
+		return nil
+	},
+	"env": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.TestEnvironments = append(filter.TestEnvironments, term.values...)
+		return nil
+	},
+	"folder": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.PlannedTestCaseFolder = append(filter.PlannedTestCaseFolder, term.values...)
+		return nil
+	},
+	"archive": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.ArchiveFiles = append(filter.ArchiveFiles, term.values...)
+		return nil
+	},
+	"report": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		for _, value := range term.values {
+			reportId, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid report ID '%s'", value)
+			}
+			filter.AtxIds = append(filter.AtxIds, reportId)
+		}
+		return nil
+	},
+	"attr": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		keyValues, err := parseKeyValuesFilter(term)
+		if err != nil {
+			return err
+		}
+		filter.Attributes = append(filter.Attributes, keyValues)
+		return nil
+	},
+	"const": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		keyValues, err := parseKeyValuesFilter(term)
+		if err != nil {
+			return err
+		}
+		filter.Constants = append(filter.Constants, keyValues)
+		return nil
+	},
+	"since": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		if err != nil {
+			return err
+		}
+		duration, err := ParseFilterDuration(value)
+		if err != nil {
+			return err
+		}
+		filter.DateFrom = Ptr(now.Add(-duration))
+		return nil
+	},
+	"from": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		date, err := parseQueryDate(term)
+		filter.DateFrom = date
+		return err
+	},
+	"to": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		date, err := parseQueryDate(term)
+		filter.DateTo = date
+		return err
+	},
+	"mintime": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := parseQueryInt(term)
+		filter.ExecutionTimeMin = value
+		return err
+	},
+	"maxtime": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := parseQueryInt(term)
+		filter.ExecutionTimeMax = value
+		return err
+	},
+	"args": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.TestArgumentExpr = value
+		return err
+	},
+	"review": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(value) {
+		case "none":
+			filter.ReviewExists = "NO"
+		case "exists":
+			filter.ReviewExists = "YES"
+		case "any":
+			filter.ReviewExists = ""
+		default:
+			return fmt.Errorf("invalid review state '%s', expected none, exists or any", value)
+		}
+		return nil
+	},
+	"reviewverdict": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		for _, value := range term.values {
+			verdict := ReviewVerdict(strings.ToUpper(value))
+			switch verdict {
+			case REVIEW_VERDICT_NONE, REVIEW_VERDICT_PASSED, REVIEW_VERDICT_INCONCLUSIVE, REVIEW_VERDICT_FAILED, REVIEW_VERDICT_ERROR, REVIEW_VERDICT_NO_VERDICT:
+				filter.ReviewVerdicts = append(filter.ReviewVerdicts, verdict)
+			default:
+				return fmt.Errorf("invalid review verdict '%s'", value)
+			}
+		}
+		return nil
+	},
+	"obsolete": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		if err != nil {
+			return err
+		}
+		include, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid boolean '%s'", value)
+		}
+		filter.IncludeObsoleteReviews = &include
+		return nil
+	},
+	"author": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.ReviewAuthor = value
+		return err
+	},
+	"comment": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.ReviewComment = value
+		return err
+	},
+	"summary": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.ReviewSummary = value
+		return err
+	},
+	"defect": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.ReviewDefectClass = value
+		return err
+	},
+	"priority": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		filter.ReviewDefectPriority = value
+		return err
+	},
+	"tag": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.ReviewTags = append(filter.ReviewTags, term.values...)
+		return nil
+	},
+	"ticket": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		filter.ReviewTickets = append(filter.ReviewTickets, term.values...)
+		return nil
+	},
+	"invalid": func(filter *FilterParameters, term *filterQueryTerm, now time.Time) error {
+		value, err := singleValue(term)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(value) {
+		case "only":
+			filter.InvalidRuns = Ptr(VALIDITY_CONSTRAINT_ONLY_INVALID)
+		case "exclude":
+			filter.InvalidRuns = Ptr(VALIDITY_CONSTRAINT_ONLY_VALID)
+		case "all":
+			filter.InvalidRuns = Ptr(VALIDITY_CONSTRAINT_NO_CONSTRAINT)
+		default:
+			return fmt.Errorf("invalid value '%s', expected only, exclude or all", value)
+		}
+		return nil
+	},
+}
+
+// Aliases for some of the keys.
+var filterQueryKeyAliases = map[string]string{
+	"name":      "test",
+	"testcase":  "test",
+	"parameter": "paramset",
+	"atx":       "report",
+	"attribute": "attr",
+	"constant":  "const",
+}
+
+// Parses a query into filter parameters. See the top of this file for the syntax.
+func ParseFilterQuery(query string) (*FilterParameters, error) {
+	return ParseFilterQueryAt(query, time.Now())
+}
+
+// Parses a query into filter parameters with relative dates (since:) based on the given time.
+func ParseFilterQueryAt(query string, now time.Time) (*FilterParameters, error) {
+	terms, err := tokenizeFilterQuery(query)
+	if err != nil {
+		return nil, err
+	}
+	filter := &FilterParameters{}
+	for _, term := range terms {
+		key := strings.ToLower(term.key)
+		if alias, ok := filterQueryKeyAliases[key]; ok {
+			key = alias
+		}
+		handler, ok := filterQueryKeys[key]
+		if !ok {
+			return nil, &FilterQueryError{Query: query, Position: term.position, Message: fmt.Sprintf("unknown key '%s', valid keys are: %s", term.key, strings.Join(filterQueryKeyNames(), ", "))}
+		}
+		if term.negated && key != "attr" && key != "const" {
+			return nil, &FilterQueryError{Query: query, Position: term.position, Message: fmt.Sprintf("key '%s' cannot be negated, only attr and const support '!'", term.key)}
+		}
+		if len(term.values) == 0 {
+			return nil, &FilterQueryError{Query: query, Position: term.valuePosition, Message: fmt.Sprintf("missing value for key '%s'", term.key)}
+		}
+		if err := handler(filter, term, now); err != nil {
+			return nil, &FilterQueryError{Query: query, Position: term.valuePosition, Message: err.Error()}
+		}
+	}
+	return filter, nil
+}
+
+// Gets the sorted names of all supported keys.
+func filterQueryKeyNames() []string {
+	names := make([]string, 0, len(filterQueryKeys))
+	for name := range filterQueryKeys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Splits the query into its terms.
+func tokenizeFilterQuery(query string) ([]*filterQueryTerm, error) {
+	terms := []*filterQueryTerm{}
+	pos := 0
+	for {
+		// Skip whitespace
+		for pos < len(query) && querySpaceAt(query, pos) > 0 {
+			pos += querySpaceAt(query, pos)
+		}
+		if pos >= len(query) {
+			return terms, nil
+		}
+		term := &filterQueryTerm{position: pos}
+		if query[pos] == '!' {
+			term.negated = true
+			pos++
+		}
+		// Read the key
+		keyStart := pos
+		for pos < len(query) && query[pos] != ':' && querySpaceAt(query, pos) == 0 {
+			pos++
+		}
+		if pos >= len(query) || query[pos] != ':' {
+			return nil, &FilterQueryError{Query: query, Position: keyStart, Message: fmt.Sprintf("expected key:value but got '%s'", query[keyStart:pos])}
+		}
+		if pos == keyStart {
+			return nil, &FilterQueryError{Query: query, Position: keyStart, Message: "missing key before ':'"}
+		}
+		term.key = query[keyStart:pos]
+		pos++
+		term.valuePosition = pos
+		// Read the comma separated values
+		var current strings.Builder
+		hasValue := false
+		for pos < len(query) && querySpaceAt(query, pos) == 0 {
+			switch query[pos] {
+			case '"':
+				quoteStart := pos
+				pos++
+				for pos < len(query) && query[pos] != '"' {
+					if query[pos] == '\\' && pos+1 < len(query) {
+						pos++
+					}
+					current.WriteByte(query[pos])
+					pos++
+				}
+				if pos >= len(query) {
+					return nil, &FilterQueryError{Query: query, Position: quoteStart, Message: "unterminated quote"}
+				}
+				hasValue = true
+			case ',':
+				if !hasValue {
+					return nil, &FilterQueryError{Query: query, Position: pos, Message: "empty value"}
+				}
+				term.values = append(term.values, current.String())
+				current.Reset()
+				hasValue = false
+			default:
+				current.WriteByte(query[pos])
+				hasValue = true
+			}
+			pos++
+		}
+		if hasValue {
+			term.values = append(term.values, current.String())
+		} else if len(term.values) > 0 {
+			return nil, &FilterQueryError{Query: query, Position: pos, Message: "empty value"}
+		}
+		terms = append(terms, term)
+	}
+}
+
+// Gets the byte size of the whitespace character at the position in the query or 0 if it is no whitespace.
+func querySpaceAt(query string, pos int) int {
+	r, size := utf8.DecodeRuneInString(query[pos:])
+	if unicode.IsSpace(r) {
+		return size
+	}
+	return 0
+}
+
+// Parses name=value1,value2 into a key values filter.
+func parseKeyValuesFilter(term *filterQueryTerm) (*KeyValuesFilter, error) {
+	name, firstValue, found := strings.Cut(term.values[0], "=")
+	if !found || name == "" {
+		return nil, fmt.Errorf("expected %s:name=value but got '%s'", term.key, term.values[0])
+	}
+	keyValues := &KeyValuesFilter{
+		Key:    name,
+		Values: append([]string{firstValue}, term.values[1:]...),
+	}
+	if term.negated {
+		keyValues.Negated = TruePtr
+	}
+	return keyValues, nil
+}
+
+// Parses a verdict case-insensitively and rejects unknown verdicts.
+func ParseVerdict(value string) (Verdict, error) {
+	verdict := Verdict(strings.ToUpper(value))
+	switch verdict {
+	case VERDICT_NONE, VERDICT_PASSED, VERDICT_INCONCLUSIVE, VERDICT_FAILED, VERDICT_ERROR:
+		return verdict, nil
+	}
+	return "", fmt.Errorf("invalid verdict '%s', expected one of NONE, PASSED, INCONCLUSIVE, FAILED, ERROR", value)
+}
+
+func singleValue(term *filterQueryTerm) (string, error) {
+	if len(term.values) != 1 {
+		return "", fmt.Errorf("key '%s' accepts only a single value", term.key)
+	}
+	return term.values[0], nil
+}
+
+func parseQueryInt(term *filterQueryTerm) (*int, error) {
+	value, err := singleValue(term)
+	if err != nil {
+		return nil, err
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number '%s'", value)
+	}
+	return &number, nil
+}
+
+// Parses a date in the format 2006-01-02 or RFC 3339.
+func parseQueryDate(term *filterQueryTerm) (*time.Time, error) {
+	value, err := singleValue(term)
+	if err != nil {
+		return nil, err
+	}
+	if date, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return &date, nil
+	}
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD or RFC 3339", value)
+	}
+	return &date, nil
+}
+
+// Parses a duration like time.ParseDuration which additionally supports days (d) and weeks (w).
+func ParseFilterDuration(value string) (time.Duration, error) {
+	if len(value) > 1 {
+		unit := value[len(value)-1]
+		if unit == 'd' || unit == 'w' {
+			number, err := strconv.Atoi(value[:len(value)-1])
+			if err == nil && number >= 0 {
+				days := time.Duration(number) * 24 * time.Hour
+				if unit == 'w' {
+					days *= 7
+				}
+				return days, nil
+			}
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid duration '%s', expected e.g. 30m, 12h, 7d or 2w", value)
+	}
+	return duration, nil
+}
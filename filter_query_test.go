@@ -0,0 +1,85 @@
+package gotestguide
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterQuery_Parse(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	now := time.Date(2025, 8, 8, 12, 0, 0, 0, time.UTC)
+
+	// Execute
+	filter, err := ParseFilterQueryAt(`verdict:FAILED,error suite:"Brake*" attr:ECU=v2,v3 !const:SIL=1 since:7d review:none tag:"needs analysis" report:12,13`, now)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal([]Verdict{VERDICT_FAILED, VERDICT_ERROR}, filter.Verdicts)
+	assert.Equal([]string{"Brake*"}, filter.TestSuiteName)
+	assert.Equal([]*KeyValuesFilter{{Key: "ECU", Values: []string{"v2", "v3"}}}, filter.Attributes)
+	assert.Equal([]*KeyValuesFilter{{Key: "SIL", Values: []string{"1"}, Negated: TruePtr}}, filter.Constants)
+	assert.Equal(now.Add(-7*24*time.Hour), *filter.DateFrom)
+	assert.Equal("NO", filter.ReviewExists)
+	assert.Equal([]string{"needs analysis"}, filter.ReviewTags)
+	assert.Equal([]int64{12, 13}, filter.AtxIds)
+}
+
+func TestFilterQuery_ParseQuotedComma(t *testing.T) {
+	// Execute
+	filter, err := ParseFilterQuery(`test:"a,b",c suite:"quoted \"name\""`)
+
+	// Verify
+	assert.NoError(t, err, "Should not return an error")
+	assert.Equal(t, []string{"a,b", "c"}, filter.TestCaseName)
+	assert.Equal(t, []string{`quoted "name"`}, filter.TestSuiteName)
+}
+
+func TestFilterQuery_ParseNonAscii(t *testing.T) {
+	// Execute
+	filter, err := ParseFilterQuery("name:Šum\u00a0suite:\"Bremse Ü\",Ära")
+
+	// Verify
+	assert.NoError(t, err, "Should not return an error")
+	assert.Equal(t, []string{"Šum"}, filter.TestCaseName)
+	assert.Equal(t, []string{"Bremse Ü", "Ära"}, filter.TestSuiteName, "Should split at non-ASCII whitespace")
+}
+
+func TestFilterQuery_ErrorNonAscii(t *testing.T) {
+	// Execute
+	_, err := ParseFilterQuery("suite:Bremsü verdic:ERROR")
+
+	// Verify
+	assert.ErrorContains(t, err, "invalid query at position 14: unknown key 'verdic'")
+	assert.ErrorContains(t, err, "\n  suite:Bremsü verdic:ERROR\n               ^", "Should place the caret by characters")
+}
+
+func TestFilterQuery_ParseErrors(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		query    string
+		position int
+		message  string
+	}{
+		{"verdict:FAILED verdic:ERROR", 15, "unknown key 'verdic'"},
+		{"verdict:BROKEN", 8, "invalid verdict 'BROKEN'"},
+		{"suite", 0, "expected key:value"},
+		{"suite:", 6, "missing value for key 'suite'"},
+		{`suite:"Brake`, 6, "unterminated quote"},
+		{"!verdict:FAILED", 0, "cannot be negated"},
+		{"attr:ECU", 5, "expected attr:name=value"},
+		{"since:yesterday", 6, "invalid duration"},
+		{"verdict:FAILED,,ERROR", 15, "empty value"},
+	}
+	for _, test := range tests {
+		_, err := ParseFilterQuery(test.query)
+		var queryErr *FilterQueryError
+		if assert.True(errors.As(err, &queryErr), "Query '%s' should return a query error", test.query) {
+			assert.Equal(test.position, queryErr.Position, "Position for query '%s'", test.query)
+			assert.Contains(queryErr.Message, test.message, "Message for query '%s'", test.query)
+		}
+	}
+}
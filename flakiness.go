@@ -0,0 +1,136 @@
+package gotestguide
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// FlakinessResult
+////////////////////////////////////////////////////////////
+
+// The flakiness analysis of a single test in a single test environment.
+type FlakinessResult struct {
+	Key TestCaseKey
+	// The test environments of the executions, formatted as sorted "key=value" pairs.
+	Environment string
+	// The number of executions with a PASSED, FAILED or ERROR verdict.
+	Executions int
+	// The number of executions with a FAILED or ERROR verdict.
+	Failures int
+	// The number of changes between passing and failing in chronological order.
+	Flips int
+	// Failures divided by executions.
+	FailureRate float64
+	// Flips divided by the number of possible flips (executions - 1).
+	FlipRate float64
+	// The flakiness score between 0 (stable) and 1 (alternating between passing and failing).
+	// It is the flip rate weighted by how close the failure rate is to 50%, so tests which
+	// always pass or always fail get a score of 0.
+	Score         float64
+	LastVerdict   Verdict
+	LastExecution time.Time
+}
+
+func (f *FlakinessResult) String() string {
+	return fmt.Sprintf("FlakinessResult(Key: %s, Environment: %s, Executions: %d, Failures: %d, Flips: %d, Score: %.2f)",
+		f.Key, f.Environment, f.Executions, f.Failures, f.Flips, f.Score)
+}
+
+////////////////////////////////////////////////////////////
+// Analysis
+////////////////////////////////////////////////////////////
+
+type flakinessGroupKey struct {
+	testCase    TestCaseKey
+	environment string
+}
+
+// Analyzes the flakiness of the tests in the given test case executions.
+// Executions are grouped by test suite, test case name, parameter set and test environment.
+// Executions with other verdicts than PASSED, FAILED or ERROR are ignored.
+// The results are sorted by descending score, then by the number of flips.
+func AnalyzeFlakiness(tces []*TestCaseExecution) []*FlakinessResult {
+	groups := map[flakinessGroupKey][]*TestCaseExecution{}
+	keys := []flakinessGroupKey{}
+	for _, tce := range tces {
+		verdict := tce.GetEffectiveVerdict()
+		if verdict != VERDICT_PASSED && !isFailingVerdict(verdict) {
+			continue
+		}
+		key := flakinessGroupKey{testCase: tce.GetTestCaseKey(), environment: formatTestEnvironments(tce.TestEnvironments)}
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], tce)
+	}
+
+	results := []*FlakinessResult{}
+	for _, key := range keys {
+		results = append(results, analyzeFlakinessGroup(key, groups[key]))
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Flips > results[j].Flips
+	})
+	return results
+}
+
+func analyzeFlakinessGroup(key flakinessGroupKey, tces []*TestCaseExecution) *FlakinessResult {
+	sort.SliceStable(tces, func(i, j int) bool {
+		return tces[i].ExecutionTimestamp.Before(tces[j].ExecutionTimestamp)
+	})
+	result := &FlakinessResult{Key: key.testCase, Environment: key.environment, Executions: len(tces)}
+	for i, tce := range tces {
+		failing := isFailingVerdict(tce.GetEffectiveVerdict())
+		if failing {
+			result.Failures++
+		}
+		if i > 0 && failing != isFailingVerdict(tces[i-1].GetEffectiveVerdict()) {
+			result.Flips++
+		}
+	}
+	last := tces[len(tces)-1]
+	result.LastVerdict = last.GetEffectiveVerdict()
+	result.LastExecution = last.ExecutionTimestamp
+	result.FailureRate = float64(result.Failures) / float64(result.Executions)
+	if result.Executions > 1 {
+		result.FlipRate = float64(result.Flips) / float64(result.Executions-1)
+	}
+	result.Score = result.FlipRate * 2 * min(result.FailureRate, 1-result.FailureRate)
+	return result
+}
+
+// Formats the test environments as sorted "key=value" pairs.
+func formatTestEnvironments(environments []*TestEnvironment) string {
+	pairs := make([]string, 0, len(environments))
+	for _, env := range environments {
+		pairs = append(pairs, env.Key+"="+env.Value)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ", ")
+}
+
+// Analyzes the flakiness of all test case executions matching the filter parameters which were executed
+// in the given time window. Zero times leave the corresponding side of the window open.
+func (s *ReportManagementService) AnalyzeFlakiness(projectId int, filter *FilterParameters, from time.Time, to time.Time) ([]*FlakinessResult, error) {
+	windowFilter := &FilterParameters{}
+	if filter != nil {
+		*windowFilter = *filter
+	}
+	if !from.IsZero() {
+		windowFilter.DateFrom = &from
+	}
+	if !to.IsZero() {
+		windowFilter.DateTo = &to
+	}
+	tces, err := s.GetAllTestCaseExecutionsByFilter(projectId, windowFilter)
+	if err != nil {
+		return nil, err
+	}
+	return AnalyzeFlakiness(tces), nil
+}
@@ -0,0 +1,99 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyzeFlakiness(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tces := []*TestCaseExecution{}
+	addExecutions := func(name string, environment string, verdicts ...Verdict) {
+		for i, verdict := range verdicts {
+			tces = append(tces, &TestCaseExecution{
+				TestSuiteName:      "Suite",
+				TestCaseName:       name,
+				Verdict:            verdict,
+				ExecutionTimestamp: start.Add(time.Duration(i) * time.Hour),
+				TestEnvironments:   []*TestEnvironment{{Key: "Bench", Value: environment}},
+			})
+		}
+	}
+	addExecutions("Stable", "HIL1", VERDICT_PASSED, VERDICT_PASSED, VERDICT_PASSED, VERDICT_PASSED)
+	addExecutions("Broken", "HIL1", VERDICT_FAILED, VERDICT_ERROR, VERDICT_FAILED, VERDICT_FAILED)
+	addExecutions("Alternating", "HIL1", VERDICT_PASSED, VERDICT_FAILED, VERDICT_PASSED, VERDICT_FAILED, VERDICT_NONE)
+	addExecutions("Sometimes", "HIL1", VERDICT_PASSED, VERDICT_PASSED, VERDICT_FAILED, VERDICT_PASSED, VERDICT_PASSED)
+	addExecutions("Sometimes", "HIL2", VERDICT_PASSED, VERDICT_PASSED)
+	// Executions are ordered by their timestamp, not their position
+	tces[8], tces[9] = tces[9], tces[8]
+
+	// Execute
+	results := AnalyzeFlakiness(tces)
+
+	// Verify
+	assert.Len(results, 5, "Should group by test and environment")
+	alternating := results[0]
+	assert.Equal("Alternating", alternating.Key.TestCaseName, "Alternating test should be the flakiest")
+	assert.Equal(4, alternating.Executions, "NONE verdicts should be ignored")
+	assert.Equal(2, alternating.Failures)
+	assert.Equal(3, alternating.Flips)
+	assert.Equal(1.0, alternating.Score)
+	assert.Equal(VERDICT_FAILED, alternating.LastVerdict)
+
+	sometimes := results[1]
+	assert.Equal("Sometimes", sometimes.Key.TestCaseName)
+	assert.Equal("Bench=HIL1", sometimes.Environment)
+	assert.Equal(2, sometimes.Flips)
+	assert.InDelta(0.2, sometimes.FailureRate, 0.0001)
+	assert.InDelta(0.5, sometimes.FlipRate, 0.0001)
+	assert.InDelta(0.2, sometimes.Score, 0.0001)
+
+	for _, result := range results[2:] {
+		assert.Equal(0.0, result.Score, "%s should not be flaky", result.Key)
+	}
+}
+
+func TestReportManagement_AnalyzeFlakiness(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	mux.HandleFunc("/api/report/testCaseExecutions/filter", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "projectId", "1")
+		var received FilterParameters
+		assert.NoError(json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal([]string{"Suite"}, received.TestSuiteName, "Filter should be kept")
+		if assert.NotNil(received.DateFrom, "Start of the window should be sent") {
+			assert.True(from.Equal(*received.DateFrom))
+		}
+		assert.Nil(received.DateTo, "Open end of the window should not be sent")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") != "0" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[
+			{"id": 1, "testCaseName": "Test", "verdict": "PASSED", "executionTimestamp": "2025-01-01T10:00:00Z"},
+			{"id": 2, "testCaseName": "Test", "verdict": "FAILED", "executionTimestamp": "2025-01-02T10:00:00Z"}
+		]`))
+	})
+	filter := &FilterParameters{TestSuiteName: []string{"Suite"}}
+
+	// Execute
+	results, err := client.ReportManagement.AnalyzeFlakiness(1, filter, from, time.Time{})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Nil(filter.DateFrom, "Passed filter should not be modified")
+	if assert.Len(results, 1) {
+		assert.Equal(1, results[0].Flips)
+		assert.Equal(1.0, results[0].Score)
+	}
+}
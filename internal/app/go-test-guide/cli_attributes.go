@@ -0,0 +1,88 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"strings"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Parses attributes in the form KEY=VALUE. Multiple values of the same key are combined into one attribute.
+func ParseAttributes(values []string) ([]*gotestguide.Attribute, error) {
+	keys := []string{}
+	valuesByKey := map[string][]string{}
+	for _, value := range values {
+		key, attributeValue, found := strings.Cut(value, "=")
+		key = strings.TrimSpace(key)
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid attribute %q, expected KEY=VALUE", value)
+		}
+		if _, ok := valuesByKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		valuesByKey[key] = append(valuesByKey[key], attributeValue)
+	}
+	attributes := make([]*gotestguide.Attribute, len(keys))
+	for i, key := range keys {
+		attributes[i] = &gotestguide.Attribute{Key: key}
+		if keyValues := valuesByKey[key]; len(keyValues) == 1 {
+			attributes[i].Value = keyValues[0]
+		} else {
+			attributes[i].Values = keyValues
+		}
+	}
+	return attributes, nil
+}
+
+// Applies the attribute changes to the given test case executions or, if none are given, to all test case executions matching the filter.
+func UpdateAttributes(client *gotestguide.Client, projectId int, tceIds []int64, filter *gotestguide.FilterParameters, update *gotestguide.AttributeUpdate, workers int, dryRun bool) error {
+	if len(update.Set) == 0 && len(update.Remove) == 0 {
+		return fmt.Errorf("no attributes to set or remove")
+	}
+	if len(tceIds) > 0 && filter != nil {
+		return fmt.Errorf("test case executions and a filter cannot be combined")
+	}
+	if len(tceIds) == 0 && filter == nil {
+		return fmt.Errorf("either test case executions or a filter is required")
+	}
+	if dryRun {
+		if filter != nil {
+			tces, err := client.ReportManagement.GetAllTestCaseExecutionsByFilter(projectId, filter)
+			if err != nil {
+				return fmt.Errorf("failed to get test case executions: %w", err)
+			}
+			for _, tce := range tces {
+				tceIds = append(tceIds, tce.ID)
+			}
+		}
+		for _, tceId := range tceIds {
+			fmt.Printf("Would update %d\n", tceId)
+		}
+		fmt.Printf("%d test case executions would be updated: %s\n", len(tceIds), update)
+		return nil
+	}
+
+	var results []*gotestguide.BulkAttributeResult
+	if filter != nil {
+		var err error
+		if results, err = client.ReportManagement.UpdateAttributesByFilter(projectId, filter, update, workers); err != nil {
+			return err
+		}
+	} else {
+		results = client.ReportManagement.UpdateAttributes(tceIds, update, workers)
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			fmt.Printf("FAILED  %d: %v\n", result.TceID, result.Error)
+		} else {
+			fmt.Printf("UPDATED %d\n", result.TceID)
+		}
+	}
+	fmt.Printf("Total: %d, Updated: %d, Failed: %d\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d updates failed", failed)
+	}
+	return nil
+}
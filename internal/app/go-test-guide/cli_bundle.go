@@ -0,0 +1,42 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+func ExportReport(client *gotestguide.Client, reportId int64, dir string, converter string, skipArtifacts bool, workers int) error {
+	manifest, err := client.ReportManagement.ExportReport(reportId, dir, &gotestguide.ReportExportOptions{
+		ConverterID:   converter,
+		SkipArtifacts: skipArtifacts,
+		Workers:       workers,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export report: %w", err)
+	}
+	files := 0
+	for _, entry := range manifest.TestCaseExecutions {
+		files += len(entry.Artifacts)
+	}
+	fmt.Printf("Exported report %d with %d test case executions and %d files to %s\n", reportId, len(manifest.TestCaseExecutions), files, dir)
+	return nil
+}
+
+func ImportReport(client *gotestguide.Client, projectId int, dir string, converter string, wait bool) error {
+	task, _, err := client.ReportManagement.ImportReport(projectId, dir, converter)
+	if err != nil {
+		return fmt.Errorf("failed to import report: %w", err)
+	}
+	fmt.Println("Report uploaded successfully. Task ID:", task.TaskID)
+	if !wait {
+		return nil
+	}
+	status, _, err := client.ReportManagement.WaitForUpload(task.TaskID, time.Second, 0)
+	if err != nil {
+		return fmt.Errorf("failed to import report: %w", err)
+	}
+	printUploadStatus(status)
+	return nil
+}
@@ -0,0 +1,30 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"os"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Compares two reports and prints or writes the comparison as Markdown.
+func DiffReports(client *gotestguide.Client, baseReportId int64, headReportId int64, outputPath string, failOnRegression bool) error {
+	diff, err := client.ReportManagement.CompareReports(baseReportId, headReportId)
+	if err != nil {
+		return fmt.Errorf("failed to compare reports: %w", err)
+	}
+	markdown := diff.Markdown()
+	if outputPath != "" {
+		if err := os.WriteFile(outputPath, []byte(markdown), 0644); err != nil {
+			return fmt.Errorf("failed to write output file %s: %w", outputPath, err)
+		}
+		fmt.Printf("Wrote comparison to %s\n", outputPath)
+	} else {
+		fmt.Print(markdown)
+	}
+	newFailures := len(diff.ByStatus(gotestguide.TEST_CASE_DIFF_STATUS_NEW_FAILURE))
+	if failOnRegression && newFailures > 0 {
+		return fmt.Errorf("found %d new failures", newFailures)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package gotestguideapp
+
+import (
+	"fmt"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Downloads all artifacts and review attachments of a test case execution, a report or a query source into a directory tree.
+func DownloadArtifacts(client *gotestguide.Client, tceId int64, reportId int64, source *QuerySource, dir string, workers int) error {
+	tces, err := getTestCaseExecutionsForDownload(client, tceId, reportId, source)
+	if err != nil {
+		return err
+	}
+	results := client.ReportManagement.DownloadTestCaseExecutionFiles(tces, dir, workers)
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			fmt.Printf("FAILED     %s: %v\n", result.Path, result.Error)
+		} else {
+			fmt.Printf("DOWNLOADED %s\n", result.Path)
+		}
+	}
+	fmt.Printf("Test case executions: %d, Files: %d, Failed: %d\n", len(tces), len(results), failed)
+	if failed > 0 {
+		return fmt.Errorf("%d files failed to download", failed)
+	}
+	return nil
+}
+
+func getTestCaseExecutionsForDownload(client *gotestguide.Client, tceId int64, reportId int64, source *QuerySource) ([]*gotestguide.TestCaseExecution, error) {
+	switch {
+	case tceId != 0 && reportId != 0:
+		return nil, fmt.Errorf("only one of test case execution and report can be used")
+	case tceId != 0:
+		tce, _, err := client.ReportManagement.GetTestCaseExecution(tceId)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test case execution %d: %w", tceId, err)
+		}
+		return []*gotestguide.TestCaseExecution{tce}, nil
+	case reportId != 0:
+		return client.ReportManagement.GetAllTestCaseExecutionsOfReport(reportId)
+	}
+	return GetTestCaseExecutions(client, source)
+}
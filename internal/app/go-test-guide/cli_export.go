@@ -0,0 +1,44 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Exports the test case executions of the source as JUnit XML or HTML report.
+func Export(client *gotestguide.Client, source *QuerySource, format string, outputPath string, title string) error {
+	tces, err := GetTestCaseExecutions(client, source)
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if outputPath != "" {
+		file, err := os.Create(outputPath)
+		if err != nil {
+			return fmt.Errorf("failed to create output file %s: %w", outputPath, err)
+		}
+		defer file.Close()
+		w = file
+	}
+
+	switch strings.ToLower(format) {
+	case "junit":
+		err = gotestguide.WriteJUnitReport(w, tces)
+	case "html":
+		err = gotestguide.WriteHTMLReport(w, tces, title)
+	default:
+		return fmt.Errorf("unknown format '%s', expected junit or html", format)
+	}
+	if err != nil {
+		return err
+	}
+	if outputPath != "" {
+		fmt.Printf("Exported %d test case executions to %s\n", len(tces), outputPath)
+	}
+	return nil
+}
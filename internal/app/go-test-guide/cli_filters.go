@@ -0,0 +1,98 @@
+package gotestguideapp
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+var invalidFileNameCharacters = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// Exports all filters of the project as JSON files into the given directory.
+func ExportFilters(client *gotestguide.Client, projectId int, dir string) error {
+	filters, err := getAllFilters(client, projectId)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+	for _, filterInfo := range filters {
+		filter, _, err := client.ReportManagement.GetFilter(filterInfo.FilterId)
+		if err != nil {
+			return fmt.Errorf("failed to get filter %d: %w", filterInfo.FilterId, err)
+		}
+		fileBytes, err := json.MarshalIndent(filter, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal filter %d: %w", filter.FilterId, err)
+		}
+		filePath := filepath.Join(dir, fmt.Sprintf("%d_%s.json", filter.FilterId, invalidFileNameCharacters.ReplaceAllString(filter.Name, "_")))
+		if err := os.WriteFile(filePath, fileBytes, 0644); err != nil {
+			return fmt.Errorf("failed to write filter file %s: %w", filePath, err)
+		}
+		fmt.Printf("Exported filter %d (%s) to %s\n", filter.FilterId, filter.Name, filePath)
+	}
+	return nil
+}
+
+// Applies all filter files from the given directory to the project.
+// Filters with the same name are updated, all others are created.
+func ApplyFilters(client *gotestguide.Client, projectId int, dir string) error {
+	filePaths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return fmt.Errorf("failed to list filter files: %w", err)
+	}
+	existingFilters, err := getAllFilters(client, projectId)
+	if err != nil {
+		return err
+	}
+	existingIds := map[string]int64{}
+	for _, filter := range existingFilters {
+		existingIds[filter.Name] = filter.FilterId
+	}
+	for _, filePath := range filePaths {
+		fileBytes, err := os.ReadFile(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to read filter file %s: %w", filePath, err)
+		}
+		filter := &gotestguide.Filter{}
+		if err := json.Unmarshal(fileBytes, filter); err != nil {
+			return fmt.Errorf("failed to parse filter file %s: %w", filePath, err)
+		}
+		if existingId, ok := existingIds[filter.Name]; ok {
+			filter.FilterId = existingId
+			if _, _, err := client.ReportManagement.UpdateFilter(filter); err != nil {
+				return fmt.Errorf("failed to update filter %s: %w", filter.Name, err)
+			}
+			fmt.Printf("Updated filter %d (%s)\n", existingId, filter.Name)
+		} else {
+			filter.FilterId = 0
+			createdFilter, _, err := client.ReportManagement.CreateFilter(projectId, filter)
+			if err != nil {
+				return fmt.Errorf("failed to create filter %s: %w", filter.Name, err)
+			}
+			fmt.Printf("Created filter %d (%s)\n", createdFilter.FilterId, filter.Name)
+		}
+	}
+	return nil
+}
+
+// Gets all filters of the project by paging through the results.
+func getAllFilters(client *gotestguide.Client, projectId int) ([]*gotestguide.FilterInformation, error) {
+	const pageSize = 100
+	ret := []*gotestguide.FilterInformation{}
+	for offset := 0; ; offset += pageSize {
+		filters, _, err := client.ReportManagement.GetFilters(projectId, gotestguide.Ptr(offset), gotestguide.Ptr(pageSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get filters: %w", err)
+		}
+		ret = append(ret, filters...)
+		if len(filters) < pageSize {
+			return ret, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Prints the flakiest tests of the execution history in the given time window.
+// A zero window keeps the dates of the filter.
+func Flaky(client *gotestguide.Client, projectId int, filter *gotestguide.FilterParameters, window time.Duration, minExecutions int, top int) error {
+	from := time.Time{}
+	if window > 0 {
+		from = time.Now().Add(-window)
+	}
+	results, err := client.ReportManagement.AnalyzeFlakiness(projectId, filter, from, time.Time{})
+	if err != nil {
+		return fmt.Errorf("failed to analyze flakiness: %w", err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SCORE\tFLIPS\tFAILURES\tEXECUTIONS\tLAST\tTEST\tENVIRONMENT")
+	shown := 0
+	for _, result := range results {
+		if result.Score == 0 || result.Executions < minExecutions {
+			continue
+		}
+		if top > 0 && shown >= top {
+			break
+		}
+		fmt.Fprintf(tw, "%.2f\t%d\t%d\t%d\t%s\t%s\t%s\n", result.Score, result.Flips, result.Failures, result.Executions,
+			result.LastVerdict, result.Key, result.Environment)
+		shown++
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d flaky tests out of %d analyzed tests\n", countFlaky(results, minExecutions), len(results))
+	return nil
+}
+
+func countFlaky(results []*gotestguide.FlakinessResult, minExecutions int) int {
+	count := 0
+	for _, result := range results {
+		if result.Score > 0 && result.Executions >= minExecutions {
+			count++
+		}
+	}
+	return count
+}
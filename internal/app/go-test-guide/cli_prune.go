@@ -0,0 +1,43 @@
+package gotestguideapp
+
+import (
+	"fmt"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Deletes the reports of a project which match the prune options and prints the outcome per report.
+func Prune(client *gotestguide.Client, projectId int, options *gotestguide.PruneOptions) error {
+	results, err := client.ReportManagement.PruneReports(projectId, options)
+	if err != nil {
+		return fmt.Errorf("failed to prune reports: %w", err)
+	}
+
+	kept, deleted, failed := 0, 0, 0
+	for _, result := range results {
+		report := result.Report
+		switch {
+		case result.KeepReason != "":
+			kept++
+			fmt.Printf("KEEP          %d (%s, %s): %s\n", report.ReportID, report.TestPlanName, report.ExecutionDate.Format("2006-01-02"), result.KeepReason)
+		case result.Error != nil:
+			failed++
+			fmt.Printf("FAILED        %d (%s, %s): %v\n", report.ReportID, report.TestPlanName, report.ExecutionDate.Format("2006-01-02"), result.Error)
+		case options.DryRun:
+			deleted++
+			fmt.Printf("WOULD DELETE  %d (%s, %s)\n", report.ReportID, report.TestPlanName, report.ExecutionDate.Format("2006-01-02"))
+		default:
+			deleted++
+			fmt.Printf("DELETED       %d (%s, %s)\n", report.ReportID, report.TestPlanName, report.ExecutionDate.Format("2006-01-02"))
+		}
+	}
+	if options.DryRun {
+		fmt.Printf("Would delete %d reports, keeping %d\n", deleted, kept)
+		return nil
+	}
+	fmt.Printf("Deleted %d reports, kept %d, failed %d\n", deleted, kept, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to delete %d reports", failed)
+	}
+	return nil
+}
@@ -0,0 +1,260 @@
+package gotestguideapp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// The supported output formats for test case executions.
+const (
+	OutputFormatTable    = "table"
+	OutputFormatJson     = "json"
+	OutputFormatNdjson   = "ndjson"
+	OutputFormatCsv      = "csv"
+	OutputFormatTemplate = "template"
+)
+
+// The source of the test case executions for a query. Exactly one of the fields must be set.
+type QuerySource struct {
+	// ID of a project filter.
+	FilterId int64
+	// Name of a project filter, needs the project ID.
+	FilterName string
+	// Path to a JSON file with the filter parameters, needs the project ID.
+	FilterFile string
+	// Query in the query syntax, needs the project ID.
+	Query string
+	// ID of the project for filter names, filter files and queries.
+	ProjectId int
+}
+
+// Checks if none of the sources is set.
+func (source *QuerySource) IsEmpty() bool {
+	return source.count() == 0
+}
+
+// Counts the sources which are set.
+func (source *QuerySource) count() int {
+	sources := 0
+	for _, isSet := range []bool{source.FilterId != 0, source.FilterName != "", source.FilterFile != "", source.Query != ""} {
+		if isSet {
+			sources++
+		}
+	}
+	return sources
+}
+
+// Checks that exactly one source and, if needed, the project is set.
+func (source *QuerySource) validate() error {
+	if source.count() != 1 {
+		return fmt.Errorf("exactly one of filter ID, filter name, filter file and query is required")
+	}
+	if source.FilterId == 0 && source.ProjectId == 0 {
+		return fmt.Errorf("a project is required for filter names, filter files and queries")
+	}
+	return nil
+}
+
+// Prints all test case executions of the source in the given format.
+func Query(client *gotestguide.Client, source *QuerySource, format string, templateText string) error {
+	tces, err := GetTestCaseExecutions(client, source)
+	if err != nil {
+		return err
+	}
+	return WriteTestCaseExecutions(os.Stdout, tces, format, templateText)
+}
+
+// Gets all test case executions of the source by paging through all results.
+func GetTestCaseExecutions(client *gotestguide.Client, source *QuerySource) ([]*gotestguide.TestCaseExecution, error) {
+	if err := source.validate(); err != nil {
+		return nil, err
+	}
+
+	var tces []*gotestguide.TestCaseExecution
+	var err error
+	switch {
+	case source.Query != "" || source.FilterFile != "":
+		filter, loadErr := LoadFilterParameters(client, source)
+		if loadErr != nil {
+			return nil, loadErr
+		}
+		tces, err = client.ReportManagement.GetAllTestCaseExecutionsByFilter(source.ProjectId, filter)
+	case source.FilterName != "":
+		filterId, lookupErr := getFilterIdByName(client, source.ProjectId, source.FilterName)
+		if lookupErr != nil {
+			return nil, lookupErr
+		}
+		tces, err = client.ReportManagement.GetAllTestCaseExecutionsByProjectFilter(filterId)
+	default:
+		tces, err = client.ReportManagement.GetAllTestCaseExecutionsByProjectFilter(source.FilterId)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test case executions: %w", err)
+	}
+	return tces, nil
+}
+
+// Gets the filter parameters of the source, project filters are loaded from the server.
+func LoadFilterParameters(client *gotestguide.Client, source *QuerySource) (*gotestguide.FilterParameters, error) {
+	if err := source.validate(); err != nil {
+		return nil, err
+	}
+	switch {
+	case source.Query != "":
+		return gotestguide.ParseFilterQuery(source.Query)
+	case source.FilterFile != "":
+		fileBytes, err := os.ReadFile(source.FilterFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read filter file %s: %w", source.FilterFile, err)
+		}
+		filter := &gotestguide.FilterParameters{}
+		if err := json.Unmarshal(fileBytes, filter); err != nil {
+			return nil, fmt.Errorf("failed to parse filter file %s: %w", source.FilterFile, err)
+		}
+		return filter, nil
+	}
+	filterId := source.FilterId
+	if source.FilterName != "" {
+		var err error
+		if filterId, err = getFilterIdByName(client, source.ProjectId, source.FilterName); err != nil {
+			return nil, err
+		}
+	}
+	filter, _, err := client.ReportManagement.GetFilter(filterId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get filter %d: %w", filterId, err)
+	}
+	if filter.Parameters == nil {
+		return &gotestguide.FilterParameters{}, nil
+	}
+	return filter.Parameters, nil
+}
+
+// Writes the test case executions in the given format.
+func WriteTestCaseExecutions(w io.Writer, tces []*gotestguide.TestCaseExecution, format string, templateText string) error {
+	switch strings.ToLower(format) {
+	case "", OutputFormatTable:
+		return writeTable(w, tces)
+	case OutputFormatJson:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tces)
+	case OutputFormatNdjson:
+		encoder := json.NewEncoder(w)
+		for _, tce := range tces {
+			if err := encoder.Encode(tce); err != nil {
+				return err
+			}
+		}
+		return nil
+	case OutputFormatCsv:
+		return writeCsv(w, tces)
+	case OutputFormatTemplate:
+		return writeTemplate(w, tces, templateText)
+	}
+	return fmt.Errorf("unknown format '%s', expected one of %s, %s, %s, %s, %s", format,
+		OutputFormatTable, OutputFormatJson, OutputFormatNdjson, OutputFormatCsv, OutputFormatTemplate)
+}
+
+// The columns used for the table and the CSV output.
+var tceColumns = []struct {
+	name  string
+	value func(tce *gotestguide.TestCaseExecution) string
+}{
+	{"ID", func(tce *gotestguide.TestCaseExecution) string { return strconv.FormatInt(tce.ID, 10) }},
+	{"REPORT", func(tce *gotestguide.TestCaseExecution) string { return strconv.FormatInt(tce.ReportID, 10) }},
+	{"VERDICT", func(tce *gotestguide.TestCaseExecution) string { return string(tce.Verdict) }},
+	{"EFFECTIVE", func(tce *gotestguide.TestCaseExecution) string { return string(tce.EffectiveVerdict) }},
+	{"EXECUTED", func(tce *gotestguide.TestCaseExecution) string { return tce.ExecutionTimestamp.Format(time.RFC3339) }},
+	{"TIME", func(tce *gotestguide.TestCaseExecution) string { return strconv.Itoa(tce.ExecutionTime) }},
+	{"SUITE", func(tce *gotestguide.TestCaseExecution) string { return tce.TestSuiteName }},
+	{"TEST", func(tce *gotestguide.TestCaseExecution) string { return tce.TestCaseName }},
+	{"PARAMETERSET", func(tce *gotestguide.TestCaseExecution) string { return tce.ParameterSet }},
+}
+
+func writeTable(w io.Writer, tces []*gotestguide.TestCaseExecution) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	names := make([]string, len(tceColumns))
+	for i, column := range tceColumns {
+		names[i] = column.name
+	}
+	fmt.Fprintln(tw, strings.Join(names, "\t"))
+	for _, tce := range tces {
+		values := make([]string, len(tceColumns))
+		for i, column := range tceColumns {
+			values[i] = strings.ReplaceAll(column.value(tce), "\t", " ")
+		}
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	return tw.Flush()
+}
+
+func writeCsv(w io.Writer, tces []*gotestguide.TestCaseExecution) error {
+	cw := csv.NewWriter(w)
+	names := make([]string, len(tceColumns))
+	for i, column := range tceColumns {
+		names[i] = strings.ToLower(column.name)
+	}
+	if err := cw.Write(names); err != nil {
+		return err
+	}
+	for _, tce := range tces {
+		values := make([]string, len(tceColumns))
+		for i, column := range tceColumns {
+			values[i] = column.value(tce)
+		}
+		if err := cw.Write(values); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Executes the template for each test case execution, followed by a newline.
+func writeTemplate(w io.Writer, tces []*gotestguide.TestCaseExecution, templateText string) error {
+	if templateText == "" {
+		return fmt.Errorf("a template is required for the template format")
+	}
+	tmpl, err := template.New("tce").Funcs(template.FuncMap{
+		"join": strings.Join,
+		"json": func(v any) (string, error) {
+			bytes, err := json.Marshal(v)
+			return string(bytes), err
+		},
+	}).Parse(templateText)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	for _, tce := range tces {
+		if err := tmpl.Execute(w, tce); err != nil {
+			return fmt.Errorf("failed to execute template for test case execution %d: %w", tce.ID, err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
+
+// Finds the ID of the project filter with the given name.
+func getFilterIdByName(client *gotestguide.Client, projectId int, filterName string) (int64, error) {
+	filters, err := getAllFilters(client, projectId)
+	if err != nil {
+		return 0, err
+	}
+	for _, filter := range filters {
+		if filter.Name == filterName {
+			return filter.FilterId, nil
+		}
+	}
+	return 0, fmt.Errorf("no filter with name '%s' found in project %d", filterName, projectId)
+}
@@ -0,0 +1,98 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+func ListReleases(client *gotestguide.Client, projectId int) error {
+	releases, _, err := client.ReportManagement.GetReleases(projectId)
+	if err != nil {
+		return fmt.Errorf("failed to get releases: %w", err)
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tVERSION\tDUE\tSTATUS")
+	for _, release := range releases {
+		dueDate := ""
+		if release.DueDate != nil {
+			dueDate = release.DueDate.Format(time.DateOnly)
+		}
+		status := "open"
+		if release.Closed {
+			status = "closed"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\n", release.ID, release.Name, release.Version, dueDate, status)
+	}
+	return tw.Flush()
+}
+
+func CreateRelease(client *gotestguide.Client, projectId int, release *gotestguide.Release) error {
+	createdRelease, _, err := client.ReportManagement.CreateRelease(projectId, release)
+	if err != nil {
+		return fmt.Errorf("failed to create release: %w", err)
+	}
+	fmt.Println("Release created successfully. Release ID:", createdRelease.ID)
+	return nil
+}
+
+func CloseRelease(client *gotestguide.Client, releaseId int64) error {
+	if _, _, err := client.ReportManagement.CloseRelease(releaseId); err != nil {
+		return fmt.Errorf("failed to close release: %w", err)
+	}
+	fmt.Printf("Release %d closed successfully\n", releaseId)
+	return nil
+}
+
+// Assigns or unassigns test case executions and reports to or from a release.
+func AssignToRelease(client *gotestguide.Client, releaseId int64, tceIds []int64, reportIds []int64, unassign bool) error {
+	if len(tceIds) == 0 && len(reportIds) == 0 {
+		return fmt.Errorf("at least one test case execution or report is required")
+	}
+	if len(tceIds) > 0 {
+		var err error
+		if unassign {
+			_, err = client.ReportManagement.UnassignTestCaseExecutionsFromRelease(releaseId, tceIds)
+		} else {
+			_, err = client.ReportManagement.AssignTestCaseExecutionsToRelease(releaseId, tceIds)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update test case executions of release %d: %w", releaseId, err)
+		}
+	}
+	if len(reportIds) > 0 {
+		var err error
+		if unassign {
+			_, err = client.ReportManagement.UnassignReportsFromRelease(releaseId, reportIds)
+		} else {
+			_, err = client.ReportManagement.AssignReportsToRelease(releaseId, reportIds)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to update reports of release %d: %w", releaseId, err)
+		}
+	}
+	action := "Assigned"
+	if unassign {
+		action = "Unassigned"
+	}
+	fmt.Printf("%s %d test case executions and %d reports for release %d\n", action, len(tceIds), len(reportIds), releaseId)
+	return nil
+}
+
+func ReleaseProgress(client *gotestguide.Client, releaseId int64) error {
+	progress, _, err := client.ReportManagement.GetReleaseProgress(releaseId)
+	if err != nil {
+		return fmt.Errorf("failed to get release progress: %w", err)
+	}
+	fmt.Printf("Reports: %d, test case executions: %d, reviewed: %d, pass rate: %.1f%%\n",
+		progress.Reports, progress.TestCaseExecutions, progress.Reviewed, progress.PassRate()*100)
+	for _, verdict := range statsVerdicts {
+		if count := progress.Verdicts[verdict]; count > 0 {
+			fmt.Printf("  %-12s %d\n", verdict, count)
+		}
+	}
+	return nil
+}
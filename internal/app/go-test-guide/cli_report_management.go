@@ -2,19 +2,191 @@ package gotestguideapp
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	gotestguide "github.com/roemer/go-test-guide"
 )
 
-func UploadReport(client *gotestguide.Client, projectId int, converter, report string) error {
-	task, _, err := client.ReportManagement.UploadReport(projectId, converter, report)
+// Changes to json2atx reports before they are uploaded.
+type ReportPreparation struct {
+	// Add the CI build and git metadata to all test cases, the git metadata is read from the directory of the report.
+	AddMetadata          bool
+	MetadataAsAttributes bool
+	// Derive the OptionalReportIdentifier from the content of the report and the pipeline ID.
+	DeriveIdentifier bool
+	// ID of the pipeline, defaults to the one of the detected CI system.
+	PipelineID string
+}
+
+// Gets the pipeline ID to derive the identifiers, the given one or the one of the detected CI system.
+// Fails without one, as the same report from another run would then be detected as double upload.
+func (p *ReportPreparation) resolvePipelineID() (string, error) {
+	if p.PipelineID != "" {
+		return p.PipelineID, nil
+	}
+	if pipelineId := gotestguide.DetectBuildMetadata(".").PipelineID; pipelineId != "" {
+		return pipelineId, nil
+	}
+	return "", fmt.Errorf("no CI pipeline detected to derive the report identifier, set --pipeline-id")
+}
+
+// Checks if the report needs to be loaded and changed before the upload.
+func (p *ReportPreparation) IsEmpty() bool {
+	return p == nil || (!p.AddMetadata && !p.DeriveIdentifier)
+}
+
+// Uploads a report file or directory. json2atx report files can be prepared before the upload.
+// If wait is set, waits until the report is processed and reports double uploads.
+func UploadReport(client *gotestguide.Client, projectId int, converter, report string, include []string, exclude []string, preparation *ReportPreparation, wait bool) error {
+	info, err := os.Stat(report)
+	if err != nil {
+		return fmt.Errorf("failed to access report %s: %w", report, err)
+	}
+	var task *gotestguide.TaskRef
+	if !preparation.IsEmpty() {
+		if info.IsDir() {
+			return fmt.Errorf("CI metadata and identifiers can only be added to json2atx report files, not to directories")
+		}
+		typedReport, loadErr := prepareSingleReport(converter, report, preparation)
+		if loadErr != nil {
+			return loadErr
+		}
+		if typedReport.OptionalReportIdentifier != "" {
+			fmt.Println("Report identifier:", typedReport.OptionalReportIdentifier)
+		}
+		task, _, err = client.ReportManagement.UploadReportTypedWithFiles(projectId, typedReport, filepath.Dir(report))
+	} else if info.IsDir() {
+		task, _, err = client.ReportManagement.UploadReportDirectory(projectId, converter, report, &gotestguide.ReportArchiveOptions{
+			Include: include,
+			Exclude: exclude,
+		})
+	} else {
+		if len(include) > 0 || len(exclude) > 0 {
+			return fmt.Errorf("include and exclude patterns can only be used with a report directory")
+		}
+		task, _, err = client.ReportManagement.UploadReport(projectId, converter, report)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to upload report: %w", err)
 	}
 	fmt.Println("Report uploaded successfully. Task ID:", task.TaskID)
+	if !wait {
+		return nil
+	}
+	status, _, err := client.ReportManagement.WaitForUpload(task.TaskID, time.Second, 0)
+	if err != nil {
+		return fmt.Errorf("failed to process report: %w", err)
+	}
+	printUploadStatus(status)
+	return nil
+}
+
+// Prints the report ID of a processed upload. A double upload is a success which refers to the existing report.
+func printUploadStatus(status *gotestguide.UploadStatus) {
+	if status.UploadResult.IsDoubleUpload {
+		fmt.Println("Report was already uploaded (double upload). Existing report ID:", status.UploadResult.ReportID)
+		return
+	}
+	fmt.Println("Report processed successfully. Report ID:", status.UploadResult.ReportID)
+}
+
+// Uploads all reports matching the patterns. json2atx report files can be prepared before the upload.
+func UploadReports(client *gotestguide.Client, projectId int, converter string, patterns []string, workers int, timeout time.Duration, preparation *ReportPreparation) error {
+	options := &gotestguide.BulkUploadOptions{
+		Workers:           workers,
+		Timeout:           timeout,
+		DeriveIdentifiers: !preparation.IsEmpty() && preparation.DeriveIdentifier,
+	}
+	if options.DeriveIdentifiers {
+		pipelineId, err := preparation.resolvePipelineID()
+		if err != nil {
+			return err
+		}
+		options.PipelineID = pipelineId
+	}
+	// Expand the patterns
+	items := []*gotestguide.BulkUploadItem{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return fmt.Errorf("report %s not found", pattern)
+		}
+		for _, match := range matches {
+			item := &gotestguide.BulkUploadItem{ConverterID: converter, ReportPath: match}
+			if !preparation.IsEmpty() {
+				typedReport, err := prepareReport(converter, match, preparation)
+				if err != nil {
+					return err
+				}
+				item.Report = typedReport
+			}
+			items = append(items, item)
+		}
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no reports found")
+	}
+	fmt.Printf("Uploading %d reports\n", len(items))
+
+	// Upload the reports
+	summary, err := client.ReportManagement.BulkUpload(projectId, items, options)
+	if err != nil {
+		return fmt.Errorf("failed to upload reports: %w", err)
+	}
+	for _, result := range summary.Results {
+		switch {
+		case result.Error != nil:
+			fmt.Printf("FAILED   %s: %v\n", result.Item.ReportPath, result.Error)
+		case result.IsDoubleUpload:
+			fmt.Printf("DOUBLE   %s: Existing report ID %d\n", result.Item.ReportPath, result.ReportID)
+		default:
+			fmt.Printf("UPLOADED %s: Report ID %d\n", result.Item.ReportPath, result.ReportID)
+		}
+	}
+	fmt.Printf("Total: %d, Uploaded: %d, Double uploads: %d, Failed: %d\n",
+		len(summary.Results), len(summary.Succeeded()), len(summary.DoubleUploads()), len(summary.Failed()))
+	if failed := len(summary.Failed()); failed > 0 {
+		return fmt.Errorf("%d reports failed to upload", failed)
+	}
 	return nil
 }
 
+// Reads a json2atx report file and adds the metadata of its directory to all its test cases.
+// The identifier is derived separately, so that it covers the added metadata.
+func prepareReport(converter string, path string, preparation *ReportPreparation) (*gotestguide.UploadReport, error) {
+	if !strings.EqualFold(converter, "json2atx") {
+		return nil, fmt.Errorf("CI metadata and identifiers can only be added to json2atx reports, not to %s reports", converter)
+	}
+	// Values which are not part of the typed report would be lost when it is written again
+	report, err := gotestguide.ReadUploadReportStrict(path)
+	if err != nil {
+		return nil, err
+	}
+	if preparation.AddMetadata {
+		gotestguide.DetectBuildMetadata(filepath.Dir(path)).ApplyTo(report, preparation.MetadataAsAttributes)
+	}
+	return report, nil
+}
+
+// Reads and prepares a single json2atx report file, including its derived identifier.
+func prepareSingleReport(converter string, path string, preparation *ReportPreparation) (*gotestguide.UploadReport, error) {
+	report, err := prepareReport(converter, path, preparation)
+	if err != nil || !preparation.DeriveIdentifier {
+		return report, err
+	}
+	pipelineId, err := preparation.resolvePipelineID()
+	if err != nil {
+		return nil, err
+	}
+	return report.WithDerivedIdentifier(pipelineId)
+}
+
 func DeleteReport(client *gotestguide.Client, reportId int64) error {
 	task, _, err := client.ReportManagement.DeleteReport(reportId)
 	if err != nil {
@@ -32,3 +204,93 @@ func AddArtifact(client *gotestguide.Client, tceId int64, filePath string, comme
 	fmt.Println("Artifact added successfully")
 	return nil
 }
+
+// Adds the files to the given test case executions, the test case executions of a report or the ones matching the filter.
+func AddArtifacts(client *gotestguide.Client, projectId int, tceIds []int64, reportId int64, filter *gotestguide.FilterParameters, paths []string, options *gotestguide.AddArtifactsOptions) error {
+	var results []*gotestguide.AddArtifactsResult
+	var err error
+	switch {
+	case len(tceIds) > 0:
+		tces := make([]*gotestguide.TestCaseExecution, len(tceIds))
+		for i, tceId := range tceIds {
+			if tces[i], _, err = client.ReportManagement.GetTestCaseExecution(tceId); err != nil {
+				return fmt.Errorf("failed to get test case execution %d: %w", tceId, err)
+			}
+		}
+		results, err = client.ReportManagement.AddArtifacts(tces, paths, options)
+	case reportId != 0:
+		results, err = client.ReportManagement.AddArtifactsToReport(reportId, paths, options)
+	default:
+		results, err = client.ReportManagement.AddArtifactsByFilter(projectId, filter, paths, options)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to add artifacts: %w", err)
+	}
+
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			fmt.Printf("FAILED  %d: %v\n", result.TceID, result.Error)
+		} else {
+			fmt.Printf("ADDED   %d: %d files added, %d already attached\n", result.TceID, len(result.Added), len(result.Skipped))
+		}
+	}
+	fmt.Printf("Total: %d, Succeeded: %d, Failed: %d\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("failed to add artifacts to %d test case executions", failed)
+	}
+	return nil
+}
+
+func CreateReview(client *gotestguide.Client, tceId int64, review *gotestguide.Review) error {
+	createdReview, _, err := client.ReportManagement.CreateReview(tceId, review)
+	if err != nil {
+		return fmt.Errorf("failed to create review: %w", err)
+	}
+	fmt.Println("Review created successfully. Review ID:", createdReview.ID)
+	return nil
+}
+
+func GetReviews(client *gotestguide.Client, tceId int64) error {
+	reviews, _, err := client.ReportManagement.GetReviews(tceId)
+	if err != nil {
+		return fmt.Errorf("failed to get reviews: %w", err)
+	}
+	for _, review := range reviews {
+		fmt.Printf("%s %-12s %-10s %s\n", review.ReviewDate.Format(time.RFC3339), review.Verdict, review.Reviewer, review.Comment)
+	}
+	return nil
+}
+
+func BulkReview(client *gotestguide.Client, projectId int, filter *gotestguide.FilterParameters, review *gotestguide.Review, workers int, dryRun bool) error {
+	if dryRun {
+		tces, err := client.ReportManagement.GetAllTestCaseExecutionsByFilter(projectId, filter)
+		if err != nil {
+			return fmt.Errorf("failed to get test case executions: %w", err)
+		}
+		for _, tce := range tces {
+			fmt.Printf("Would review %d: %s / %s (%s)\n", tce.ID, tce.TestSuiteName, tce.TestCaseName, tce.Verdict)
+		}
+		fmt.Printf("%d test case executions would be reviewed\n", len(tces))
+		return nil
+	}
+	results, err := client.ReportManagement.CreateReviewsByFilter(projectId, filter, review, workers)
+	if err != nil {
+		return fmt.Errorf("failed to review test case executions: %w", err)
+	}
+	failed := 0
+	for _, result := range results {
+		if result.Error != nil {
+			failed++
+			fmt.Printf("FAILED   %d: %v\n", result.TceID, result.Error)
+		} else {
+			fmt.Printf("REVIEWED %d: Review ID %d\n", result.TceID, result.Review.ID)
+		}
+	}
+	fmt.Printf("Total: %d, Reviewed: %d, Failed: %d\n", len(results), len(results)-failed, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d reviews failed", failed)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// The supported output formats for a single test case execution.
+const (
+	ShowFormatText     = "text"
+	ShowFormatMarkdown = "markdown"
+)
+
+// Prints the details and the test step tree of a test case execution.
+func ShowTestCaseExecution(client *gotestguide.Client, tceId int64, format string) error {
+	tce, _, err := client.ReportManagement.GetTestCaseExecution(tceId)
+	if err != nil {
+		return fmt.Errorf("failed to get test case execution %d: %w", tceId, err)
+	}
+
+	var firstFailed *gotestguide.TestStepMatch
+	if tce.TestSteps != nil {
+		firstFailed = tce.TestSteps.FirstFailedStep()
+	}
+	switch strings.ToLower(format) {
+	case ShowFormatText:
+		fmt.Printf("Test case:  %s\n", tce.GetTestCaseKey())
+		fmt.Printf("ID:         %d (report %d)\n", tce.ID, tce.ReportID)
+		fmt.Printf("Verdict:    %s\n", tce.GetEffectiveVerdict())
+		fmt.Printf("Executed:   %s (%s)\n", tce.ExecutionTimestamp.Format(time.RFC3339), time.Duration(tce.ExecutionTime)*time.Second)
+		if firstFailed != nil {
+			fmt.Printf("Failed at:  %s\n", firstFailed.Path)
+		}
+		if tce.TestSteps != nil {
+			fmt.Printf("\n%s", tce.TestSteps.Text())
+		}
+	case ShowFormatMarkdown:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## %s\n\n", tce.GetTestCaseKey())
+		fmt.Fprintf(&sb, "- ID: %d (report %d)\n", tce.ID, tce.ReportID)
+		fmt.Fprintf(&sb, "- Verdict: %s\n", tce.GetEffectiveVerdict())
+		fmt.Fprintf(&sb, "- Executed: %s (%s)\n", tce.ExecutionTimestamp.Format(time.RFC3339), time.Duration(tce.ExecutionTime)*time.Second)
+		if firstFailed != nil {
+			fmt.Fprintf(&sb, "- Failed at: `%s`\n", firstFailed.Path)
+		}
+		if tce.TestSteps != nil {
+			fmt.Fprintf(&sb, "\n%s", tce.TestSteps.Markdown())
+		}
+		fmt.Print(sb.String())
+	default:
+		return fmt.Errorf("unknown format: %s", format)
+	}
+	return nil
+}
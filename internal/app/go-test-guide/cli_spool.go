@@ -0,0 +1,137 @@
+package gotestguideapp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Uploads a report file through the spool, the report is queued if the server is unreachable.
+// json2atx reports are uploaded as typed reports with the files they reference, so that a replay is detected as double upload.
+// Other reports have no report identifier, so a replay after a lost response may create the report twice.
+func SpoolUploadReport(client *gotestguide.Client, spoolDir string, projectId int, converter, report string, preparation *ReportPreparation) error {
+	spool, err := gotestguide.NewUploadSpool(client.ReportManagement, spoolDir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(report)
+	if err != nil {
+		return fmt.Errorf("failed to access report %s: %w", report, err)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("the spool only supports report files, not directories")
+	}
+	var task *gotestguide.TaskRef
+	var entry *gotestguide.SpoolEntry
+	if !preparation.IsEmpty() {
+		typedReport, loadErr := prepareSingleReport(converter, report, preparation)
+		if loadErr != nil {
+			return loadErr
+		}
+		task, entry, err = spool.UploadReportTypedWithFiles(projectId, typedReport, filepath.Dir(report))
+	} else if strings.EqualFold(converter, "json2atx") {
+		// Values which are not part of the typed report would be lost when it is written again
+		typedReport, loadErr := gotestguide.ReadUploadReportStrict(report)
+		if loadErr != nil {
+			return loadErr
+		}
+		task, entry, err = spool.UploadReportTypedWithFiles(projectId, typedReport, filepath.Dir(report))
+	} else {
+		task, entry, err = spool.UploadReport(projectId, converter, report)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to upload report: %w", err)
+	}
+	if entry != nil {
+		fmt.Println("Server unreachable, report queued. Entry ID:", entry.ID)
+		if entry.ReportIdentifier != "" {
+			fmt.Println("Report identifier:", entry.ReportIdentifier)
+		}
+		return nil
+	}
+	fmt.Println("Report uploaded successfully. Task ID:", task.TaskID)
+	return nil
+}
+
+// Adds an artifact through the spool, the artifact is queued if the server is unreachable.
+func SpoolAddArtifact(client *gotestguide.Client, spoolDir string, tceId int64, filePath string, comment string, category string) error {
+	spool, err := gotestguide.NewUploadSpool(client.ReportManagement, spoolDir)
+	if err != nil {
+		return err
+	}
+	entry, err := spool.AddArtifact(tceId, filePath, comment, category)
+	if err != nil {
+		return fmt.Errorf("failed to add artifact: %w", err)
+	}
+	if entry != nil {
+		fmt.Println("Server unreachable, artifact queued. Entry ID:", entry.ID)
+		return nil
+	}
+	fmt.Println("Artifact added successfully")
+	return nil
+}
+
+// Prints the queued or the failed entries of the spool.
+func ListSpool(client *gotestguide.Client, spoolDir string, failed bool) error {
+	spool, err := gotestguide.NewUploadSpool(client.ReportManagement, spoolDir)
+	if err != nil {
+		return err
+	}
+	var entries []*gotestguide.SpoolEntry
+	if failed {
+		entries, err = spool.ListFailed()
+	} else {
+		entries, err = spool.List()
+	}
+	if err != nil {
+		return err
+	}
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTYPE\tCREATED\tTARGET\tFILE\tERROR")
+	for _, entry := range entries {
+		target := fmt.Sprintf("project %d", entry.ProjectID)
+		if entry.Type == gotestguide.SPOOL_ENTRY_TYPE_ARTIFACT {
+			target = fmt.Sprintf("tce %d", entry.TceID)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", entry.ID, entry.Type, entry.CreatedAt.Format(time.DateTime), target, entry.FileName, entry.Error)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("%d entries\n", len(entries))
+	return nil
+}
+
+// Replays the queued entries of the spool in order.
+func FlushSpool(client *gotestguide.Client, spoolDir string) error {
+	spool, err := gotestguide.NewUploadSpool(client.ReportManagement, spoolDir)
+	if err != nil {
+		return err
+	}
+	results, err := spool.Flush()
+	failed := 0
+	for _, result := range results {
+		switch {
+		case result.Error != nil:
+			failed++
+			fmt.Printf("FAILED %s (%s): %v\n", result.Entry.ID, result.Entry.Type, result.Error)
+		case result.TaskRef != nil:
+			fmt.Printf("SENT   %s (%s): Task ID %s\n", result.Entry.ID, result.Entry.Type, result.TaskRef.TaskID)
+		default:
+			fmt.Printf("SENT   %s (%s)\n", result.Entry.ID, result.Entry.Type)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to flush spool: %w", err)
+	}
+	fmt.Printf("Flushed %d entries, %d failed\n", len(results), failed)
+	if failed > 0 {
+		return fmt.Errorf("%d entries failed, see 'spool-list --failed'", failed)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package gotestguideapp
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Prints the statistics of the test case executions matching the filter parameters.
+// The table format prints a summary with the time series and value counts, the csv format prints the time series only.
+func Stats(client *gotestguide.Client, projectId int, filter *gotestguide.FilterParameters, interval string, format string) error {
+	stats, err := client.ReportManagement.GetStatistics(projectId, filter, gotestguide.StatisticsInterval(strings.ToLower(interval)))
+	if err != nil {
+		return fmt.Errorf("failed to get statistics: %w", err)
+	}
+	switch strings.ToLower(format) {
+	case "", OutputFormatTable:
+		return writeStatsTable(os.Stdout, stats)
+	case OutputFormatJson:
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	case OutputFormatCsv:
+		return writeStatsCsv(os.Stdout, stats)
+	}
+	return fmt.Errorf("unknown format '%s', expected one of %s, %s, %s", format, OutputFormatTable, OutputFormatJson, OutputFormatCsv)
+}
+
+// The verdicts which are shown as columns of the time series.
+var statsVerdicts = []gotestguide.Verdict{
+	gotestguide.VERDICT_PASSED,
+	gotestguide.VERDICT_FAILED,
+	gotestguide.VERDICT_ERROR,
+	gotestguide.VERDICT_INCONCLUSIVE,
+	gotestguide.VERDICT_NONE,
+}
+
+func writeStatsTable(w io.Writer, stats *gotestguide.TestCaseExecutionStatistics) error {
+	fmt.Fprintf(w, "Executions: %d, pass rate: %.1f%%, mean time: %.1fs, p95 time: %.1fs\n\n",
+		stats.Total, stats.PassRate*100, stats.MeanExecutionTime, stats.P95ExecutionTime)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	header := []string{strings.ToUpper(string(stats.Interval)), "TOTAL", "PASS RATE"}
+	for _, verdict := range statsVerdicts {
+		header = append(header, string(verdict))
+	}
+	header = append(header, "MEAN TIME", "P95 TIME")
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, bucket := range stats.Series {
+		fmt.Fprintln(tw, strings.Join(statsBucketValues(bucket), "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	for _, section := range []struct {
+		name   string
+		counts map[string]map[string]int
+	}{
+		{"Test environments", stats.TestEnvironments},
+		{"Attributes", stats.Attributes},
+	} {
+		if len(section.counts) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "\n%s:\n", section.name)
+		for _, key := range sortedKeys(section.counts) {
+			values := section.counts[key]
+			pairs := []string{}
+			for _, value := range sortedKeys(values) {
+				pairs = append(pairs, fmt.Sprintf("%s (%d)", value, values[value]))
+			}
+			fmt.Fprintf(w, "  %s: %s\n", key, strings.Join(pairs, ", "))
+		}
+	}
+	return nil
+}
+
+func writeStatsCsv(w io.Writer, stats *gotestguide.TestCaseExecutionStatistics) error {
+	cw := csv.NewWriter(w)
+	header := []string{"start", "total", "passrate"}
+	for _, verdict := range statsVerdicts {
+		header = append(header, strings.ToLower(string(verdict)))
+	}
+	header = append(header, "meantime", "p95time")
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, bucket := range stats.Series {
+		if err := cw.Write(statsBucketValues(bucket)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func statsBucketValues(bucket *gotestguide.StatisticsBucket) []string {
+	values := []string{bucket.Start.Format(time.DateOnly), strconv.Itoa(bucket.Total), strconv.FormatFloat(bucket.PassRate, 'f', 3, 64)}
+	for _, verdict := range statsVerdicts {
+		values = append(values, strconv.Itoa(bucket.Verdicts[verdict]))
+	}
+	return append(values, strconv.FormatFloat(bucket.MeanExecutionTime, 'f', 1, 64), strconv.FormatFloat(bucket.P95ExecutionTime, 'f', 1, 64))
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
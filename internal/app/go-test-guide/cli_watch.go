@@ -0,0 +1,40 @@
+package gotestguideapp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	gotestguide "github.com/roemer/go-test-guide"
+)
+
+// Watches a directory and uploads the dropped report files until the process is interrupted.
+func Watch(ctx context.Context, client *gotestguide.Client, options *gotestguide.ReportWatcherOptions) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	options.OnResult = func(result *gotestguide.ReportWatcherResult) {
+		switch {
+		case result.WillRetry:
+			fmt.Printf("RETRY    %s: %v\n", result.File, result.Error)
+		case result.Error != nil:
+			fmt.Printf("FAILED   %s: %v\n", result.File, result.Error)
+		case result.IsDoubleUpload:
+			fmt.Printf("DOUBLE   %s: Report ID %d\n", result.File, result.ReportID)
+		default:
+			fmt.Printf("UPLOADED %s: Report ID %d\n", result.File, result.ReportID)
+		}
+	}
+	watcher, err := gotestguide.NewReportWatcher(client.ReportManagement, options)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Watching %s for new reports, press Ctrl+C to stop\n", options.Dir)
+	if err := watcher.Run(ctx); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", options.Dir, err)
+	}
+	fmt.Println("Stopped watching")
+	return nil
+}
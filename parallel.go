@@ -0,0 +1,26 @@
+package gotestguide
+
+import "sync"
+
+// Runs fn for each index from 0 to count-1 with at most the given number of workers.
+func forEachParallel(count int, workers int, fn func(i int)) {
+	if workers < 1 {
+		workers = 1
+	}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, count) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+	for i := range count {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,165 @@
+package gotestguide
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Limits for a category of requests sent by the client.
+type RequestLimits struct {
+	// Maximum number of requests per second. Zero means unlimited.
+	RequestsPerSecond float64
+	// Number of requests which can be sent at once before the rate applies. Defaults to 1.
+	Burst int
+	// Maximum number of requests which are executed at the same time. Zero means unlimited.
+	MaxInFlight int
+}
+
+// Limit the rate and concurrency of upload requests (reports and artifacts).
+func WithUploadLimits(limits RequestLimits) ClientOption {
+	return func(c *Client) error {
+		limiter, err := newRequestLimiter(limits)
+		if err != nil {
+			return fmt.Errorf("invalid upload limits: %w", err)
+		}
+		c.uploadLimiter = limiter
+		return nil
+	}
+}
+
+// Limit the rate and concurrency of all other requests (queries, status checks, ...).
+func WithQueryLimits(limits RequestLimits) ClientOption {
+	return func(c *Client) error {
+		limiter, err := newRequestLimiter(limits)
+		if err != nil {
+			return fmt.Errorf("invalid query limits: %w", err)
+		}
+		c.queryLimiter = limiter
+		return nil
+	}
+}
+
+// Get the limiter which is responsible for the given request.
+func (c *Client) limiterFor(req *http.Request) *requestLimiter {
+	if isUploadRequest(req) {
+		return c.uploadLimiter
+	}
+	return c.queryLimiter
+}
+
+// Checks if the request uploads a report or an artifact.
+func isUploadRequest(req *http.Request) bool {
+	if req.Method != http.MethodPost && req.Method != http.MethodPut {
+		return false
+	}
+	path := strings.TrimSuffix(req.URL.Path, "/")
+	return strings.HasSuffix(path, "/api/report/reports") || strings.HasSuffix(path, "/artifacts")
+}
+
+////////////////////////////////////////////////////////////
+// requestLimiter
+////////////////////////////////////////////////////////////
+
+// Combines a token bucket with a semaphore for the maximum number of requests in flight.
+type requestLimiter struct {
+	bucket    *tokenBucket
+	semaphore chan struct{}
+}
+
+func newRequestLimiter(limits RequestLimits) (*requestLimiter, error) {
+	if limits.RequestsPerSecond < 0 || limits.Burst < 0 || limits.MaxInFlight < 0 {
+		return nil, fmt.Errorf("limits must not be negative")
+	}
+	limiter := &requestLimiter{}
+	if limits.RequestsPerSecond > 0 {
+		limiter.bucket = newTokenBucket(limits.RequestsPerSecond, max(limits.Burst, 1))
+	}
+	if limits.MaxInFlight > 0 {
+		limiter.semaphore = make(chan struct{}, limits.MaxInFlight)
+	}
+	return limiter, nil
+}
+
+// Blocks until the request is allowed to be sent.
+// The returned function must be called once the request is done.
+func (l *requestLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	if l.semaphore != nil {
+		select {
+		case l.semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if l.semaphore != nil {
+			<-l.semaphore
+		}
+	}
+	if l.bucket != nil {
+		if err := l.bucket.wait(ctx); err != nil {
+			release()
+			return nil, err
+		}
+	}
+	return release, nil
+}
+
+////////////////////////////////////////////////////////////
+// tokenBucket
+////////////////////////////////////////////////////////////
+
+type tokenBucket struct {
+	mutex      sync.Mutex
+	rate       float64
+	burst      float64
+	tokens     float64
+	lastRefill time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{
+		rate:       rate,
+		burst:      float64(burst),
+		tokens:     float64(burst),
+		lastRefill: time.Now(),
+	}
+}
+
+// Blocks until a token is available or the context is done.
+func (b *tokenBucket) wait(ctx context.Context) error {
+	for {
+		delay := b.take()
+		if delay == 0 {
+			return nil
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+	}
+}
+
+// Takes a token if available. Otherwise returns the time until the next token is available.
+func (b *tokenBucket) take() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	now := time.Now()
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.lastRefill).Seconds()*b.rate)
+	b.lastRefill = now
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
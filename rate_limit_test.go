@@ -0,0 +1,112 @@
+package gotestguide
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_MaxInFlight(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var inFlight, maxInFlight atomic.Int32
+	mux.HandleFunc("/api/platform/projects/1", func(w http.ResponseWriter, r *http.Request) {
+		current := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for {
+			observed := maxInFlight.Load()
+			if current <= observed || maxInFlight.CompareAndSwap(observed, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"projectId": 1}`))
+	})
+	assert.NoError(WithQueryLimits(RequestLimits{MaxInFlight: 2})(client))
+
+	// Execute
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _, err := client.Platform.GetProject(1)
+			assert.NoError(err, "Should not return an error")
+		}()
+	}
+	wg.Wait()
+
+	// Verify
+	assert.LessOrEqual(maxInFlight.Load(), int32(2), "Should not exceed the maximum number of requests in flight")
+}
+
+func TestRateLimit_DoRawHoldsLimitUntilClose(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("data"))
+	})
+	assert.NoError(WithQueryLimits(RequestLimits{MaxInFlight: 1})(client))
+	req, _ := client.NewRequest(http.MethodGet, "api/report/files/1", nil)
+	resp, err := client.DoRaw(req)
+	assert.NoError(err, "Should not return an error")
+
+	// Execute
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	blockedReq, _ := client.NewRequest(http.MethodGet, "api/report/files/1", nil)
+	_, blockedErr := client.DoRaw(blockedReq.WithContext(ctx))
+	resp.Body.Close()
+	resp.Body.Close()
+	secondReq, _ := client.NewRequest(http.MethodGet, "api/report/files/1", nil)
+	secondResp, secondErr := client.DoRaw(secondReq)
+
+	// Verify
+	assert.ErrorIs(blockedErr, context.DeadlineExceeded, "Request should wait while the body is open")
+	assert.NoError(secondErr, "Request should be allowed after the body was closed")
+	secondResp.Body.Close()
+}
+
+func TestRateLimit_TokenBucket(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	bucket := newTokenBucket(50, 2)
+
+	// Execute
+	start := time.Now()
+	for range 5 {
+		assert.NoError(bucket.wait(t.Context()))
+	}
+	elapsed := time.Since(start)
+
+	// Verify
+	// 2 tokens are available immediately, the remaining 3 need 20ms each
+	assert.GreaterOrEqual(elapsed, 50*time.Millisecond, "Should wait for new tokens")
+}
+
+func TestRateLimit_IsUploadRequest(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+	newRequest := func(method, path string) *http.Request {
+		req, err := client.NewRequest(method, path, nil)
+		assert.NoError(err)
+		return req
+	}
+
+	// Verify
+	assert.True(isUploadRequest(newRequest(http.MethodPost, "api/report/reports?projectId=1&converterId=json2atx")))
+	assert.True(isUploadRequest(newRequest(http.MethodPut, "api/report/testCaseExecution/1/artifacts")))
+	assert.True(isUploadRequest(newRequest(http.MethodPost, "api/artifact/artifacts?depositoryId=dep1")))
+	assert.False(isUploadRequest(newRequest(http.MethodGet, "api/report/reports/1")))
+	assert.False(isUploadRequest(newRequest(http.MethodPost, "api/report/testCaseExecutions/filter?projectId=1")))
+}
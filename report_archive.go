@@ -0,0 +1,211 @@
+package gotestguide
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////
+// ReportArchiveOptions
+////////////////////////////////////////////////////////////
+
+// Options to select the files which are added to a report archive.
+// Patterns are matched against the slash-separated path relative to the root of the archive.
+// They support the syntax of path.Match and additionally "**" for any number of directories.
+// Patterns without a slash are also matched against the file name alone.
+// The options only apply to directories and file systems, explicitly listed files are always added.
+type ReportArchiveOptions struct {
+	// Patterns of files to add. All files are added if empty.
+	Include []string
+	// Patterns of files to skip. Takes precedence over Include.
+	Exclude []string
+}
+
+// Checks if the file with the given relative path should be added to the archive.
+func (o *ReportArchiveOptions) matches(relPath string) (bool, error) {
+	if o == nil {
+		return true, nil
+	}
+	for _, pattern := range o.Exclude {
+		if matched, err := matchArchivePattern(pattern, relPath); err != nil || matched {
+			return false, err
+		}
+	}
+	if len(o.Include) == 0 {
+		return true, nil
+	}
+	for _, pattern := range o.Include {
+		if matched, err := matchArchivePattern(pattern, relPath); err != nil || matched {
+			return matched, err
+		}
+	}
+	return false, nil
+}
+
+// Matches a slash-separated path against the pattern, supporting "**" for any number of directories.
+func matchArchivePattern(pattern string, relPath string) (bool, error) {
+	if !strings.Contains(pattern, "/") {
+		if matched, err := path.Match(pattern, path.Base(relPath)); err != nil || matched {
+			return matched, err
+		}
+	}
+	return matchPatternSegments(strings.Split(pattern, "/"), strings.Split(relPath, "/"))
+}
+
+func matchPatternSegments(patternSegments []string, pathSegments []string) (bool, error) {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0, nil
+	}
+	if patternSegments[0] == "**" {
+		// Try to match the rest of the pattern with every possible remainder of the path
+		for i := 0; i <= len(pathSegments); i++ {
+			if matched, err := matchPatternSegments(patternSegments[1:], pathSegments[i:]); err != nil || matched {
+				return matched, err
+			}
+		}
+		return false, nil
+	}
+	if len(pathSegments) == 0 {
+		return false, nil
+	}
+	matched, err := path.Match(patternSegments[0], pathSegments[0])
+	if err != nil || !matched {
+		return false, err
+	}
+	return matchPatternSegments(patternSegments[1:], pathSegments[1:])
+}
+
+////////////////////////////////////////////////////////////
+// Archive creation
+////////////////////////////////////////////////////////////
+
+// The name of the typed report inside the archive which is uploaded with the json2atx converter.
+const typedReportFileName = "report.json"
+
+// Creates a zip archive with the files from the file system which match the options.
+func createArchiveFromFS(fsys fs.FS, options *ReportArchiveOptions) ([]byte, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	fileCount := 0
+	err := fs.WalkDir(fsys, ".", func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		matched, err := options.matches(filePath)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !matched {
+			return nil
+		}
+		fileCount++
+		return addFileToArchive(w, filePath, func() (io.ReadCloser, error) {
+			return fsys.Open(filePath)
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	if fileCount == 0 {
+		return nil, fmt.Errorf("no files found for the report archive")
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Creates a zip archive with the given files. The paths inside the archive are relative to baseDir.
+// If baseDir is empty, the files are added to the root of the archive, so their file names must be unique.
+func createArchiveFromFiles(baseDir string, filePaths []string) ([]byte, error) {
+	if len(filePaths) == 0 {
+		return nil, fmt.Errorf("no files found for the report archive")
+	}
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	entryPaths := map[string]string{}
+	for _, filePath := range filePaths {
+		entryName := filepath.Base(filePath)
+		if baseDir != "" {
+			relPath, err := filepath.Rel(baseDir, filePath)
+			if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+				return nil, fmt.Errorf("file %s is not inside %s", filePath, baseDir)
+			}
+			entryName = filepath.ToSlash(relPath)
+		}
+		if otherPath, exists := entryPaths[entryName]; exists {
+			return nil, fmt.Errorf("files %s and %s would both be added as %s to the report archive", otherPath, filePath, entryName)
+		}
+		entryPaths[entryName] = filePath
+		err := addFileToArchive(w, entryName, func() (io.ReadCloser, error) {
+			return os.Open(filePath)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Creates a zip archive with the typed report as report.json and the files it references, read relative to baseDir.
+func createTypedReportArchive(report *UploadReport, baseDir string) ([]byte, error) {
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	err = addFileToArchive(w, typedReportFileName, func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(reportBytes)), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entryName := range report.GetReferencedFiles() {
+		filePath := filepath.FromSlash(entryName)
+		if !filepath.IsLocal(filePath) || entryName == typedReportFileName {
+			return nil, fmt.Errorf("referenced file %s must be inside the report directory", entryName)
+		}
+		err := addFileToArchive(w, entryName, func() (io.ReadCloser, error) {
+			return os.Open(filepath.Join(baseDir, filePath))
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close zip writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Adds a single file to the archive.
+func addFileToArchive(w *zip.Writer, entryName string, open func() (io.ReadCloser, error)) error {
+	file, err := open()
+	if err != nil {
+		return fmt.Errorf("failed to read file %s: %w", entryName, err)
+	}
+	defer file.Close()
+	f, err := w.Create(entryName)
+	if err != nil {
+		return fmt.Errorf("failed to create zip entry for file %s: %w", entryName, err)
+	}
+	if _, err := io.Copy(f, file); err != nil {
+		return fmt.Errorf("failed to write file %s to zip: %w", entryName, err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package gotestguide
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportArchive_MatchPattern(t *testing.T) {
+	assert := assert.New(t)
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.atxml", "report.atxml", true},
+		{"*.atxml", "sub/report.atxml", true},
+		{"*.atxml", "report.xml", false},
+		{"recordings/*.mf4", "recordings/a.mf4", true},
+		{"recordings/*.mf4", "recordings/sub/a.mf4", false},
+		{"recordings/**/*.mf4", "recordings/sub/a.mf4", true},
+		{"recordings/**/*.mf4", "recordings/a.mf4", true},
+		{"**/images/*", "a/b/images/x.png", true},
+		{"**", "a/b/c.txt", true},
+	}
+	for _, test := range tests {
+		matched, err := matchArchivePattern(test.pattern, test.path)
+		assert.NoError(err)
+		assert.Equal(test.want, matched, "Pattern %s with path %s", test.pattern, test.path)
+	}
+}
+
+func TestReportArchive_CreateFromFS(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	fsys := fstest.MapFS{
+		"report.atxml":           {Data: []byte("<atx/>")},
+		"recordings/signal.mf4":  {Data: []byte("mf4")},
+		"recordings/signal.tmp":  {Data: []byte("tmp")},
+		"images/screenshot.png":  {Data: []byte("png")},
+		"images/thumbs/tiny.png": {Data: []byte("png")},
+	}
+
+	// Execute
+	archive, err := createArchiveFromFS(fsys, &ReportArchiveOptions{
+		Include: []string{"*.atxml", "recordings/**", "images/*.png"},
+		Exclude: []string{"*.tmp"},
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal([]string{"images/screenshot.png", "recordings/signal.mf4", "report.atxml"}, getArchiveEntries(t, archive))
+}
+
+func TestReportArchive_CreateFromFilesOutsideBaseDir(t *testing.T) {
+	// Execute
+	_, err := createArchiveFromFiles(filepath.Join("a", "b"), []string{filepath.Join("a", "c.txt")})
+
+	// Verify
+	assert.Error(t, err, "Should fail for files outside of the base directory")
+}
+
+func TestReportArchive_CreateFromFilesDuplicateNames(t *testing.T) {
+	// Prepare
+	dir := t.TempDir()
+	firstPath := filepath.Join(dir, "a", "report.xml")
+	secondPath := filepath.Join(dir, "b", "report.xml")
+	for _, filePath := range []string{firstPath, secondPath} {
+		assert.NoError(t, os.MkdirAll(filepath.Dir(filePath), 0755))
+		assert.NoError(t, os.WriteFile(filePath, []byte("<a/>"), 0644))
+	}
+
+	// Execute
+	_, err := createArchiveFromFiles("", []string{firstPath, secondPath})
+
+	// Verify
+	assert.ErrorContains(t, err, "report.xml", "Should fail for files with the same name in the archive root")
+}
+
+func TestReportManagement_UploadReportFiles(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var receivedEntries []string
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "converterId", "ecu.test")
+		body, _ := io.ReadAll(r.Body)
+		receivedEntries = getArchiveEntries(t, body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	baseDir := t.TempDir()
+	reportPath := filepath.Join(baseDir, "report.atxml")
+	recordingPath := filepath.Join(baseDir, "recordings", "signal.mf4")
+	assert.NoError(os.MkdirAll(filepath.Dir(recordingPath), 0755))
+	assert.NoError(os.WriteFile(reportPath, []byte("<atx/>"), 0644))
+	assert.NoError(os.WriteFile(recordingPath, []byte("mf4"), 0644))
+
+	// Execute
+	task, _, err := client.ReportManagement.UploadReportFiles(1, "ecu.test", baseDir, []string{reportPath, recordingPath})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("task1", task.TaskID, "Task ID should match expected value")
+	assert.Equal([]string{"recordings/signal.mf4", "report.atxml"}, receivedEntries)
+}
+
+func TestReportManagement_UploadReportTypedWithFiles(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var receivedEntries []string
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "converterId", "json2atx")
+		body, _ := io.ReadAll(r.Body)
+		receivedEntries = getArchiveEntries(t, body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	baseDir := t.TempDir()
+	assert.NoError(os.MkdirAll(filepath.Join(baseDir, "logs"), 0755))
+	assert.NoError(os.WriteFile(filepath.Join(baseDir, "logs", "trace.asc"), []byte("trace"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(baseDir, "plot.png"), []byte("png"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(baseDir, "unrelated.txt"), []byte("other"), 0644))
+	report := &UploadReport{Name: "Report", TestCases: []IAbstractUploadTestCase{
+		&UploadTestCase{
+			Name:               "Test",
+			Artifacts:          []string{"logs/trace.asc"},
+			ExecutionTestSteps: []IAbstractTestStep{&TestStep{Name: "Step", TestStepArtifacts: []*TestStepArtifact{{Path: "plot.png"}}}},
+		},
+	}}
+
+	// Execute
+	task, _, err := client.ReportManagement.UploadReportTypedWithFiles(1, report, baseDir)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("task1", task.TaskID)
+	assert.Equal([]string{"logs/trace.asc", "plot.png", "report.json"}, receivedEntries, "Should add the referenced files only")
+}
+
+func TestReportArchive_CreateTypedReportOutsideBaseDir(t *testing.T) {
+	// Prepare
+	report := &UploadReport{Name: "Report", TestCases: []IAbstractUploadTestCase{
+		&UploadTestCase{Name: "Test", Artifacts: []string{"../secret.txt"}},
+	}}
+
+	// Execute
+	_, err := createTypedReportArchive(report, t.TempDir())
+
+	// Verify
+	assert.ErrorContains(t, err, "inside the report directory", "Should fail for files outside the report directory")
+}
+
+// Gets the sorted names of all entries in the zip archive.
+func getArchiveEntries(t *testing.T, archive []byte) []string {
+	reader, err := zip.NewReader(bytes.NewReader(archive), int64(len(archive)))
+	if err != nil {
+		t.Fatalf("Failed to read zip archive: %v", err)
+	}
+	entries := []string{}
+	for _, file := range reader.File {
+		entries = append(entries, file.Name)
+	}
+	sort.Strings(entries)
+	return entries
+}
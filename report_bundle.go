@@ -0,0 +1,286 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// This file contains the export of reports into portable bundles on disk and their import.
+// A bundle has the following layout:
+//   manifest.json                      the ReportBundleManifest
+//   report.zip                         the original uploaded report archive
+//   tces/<tce ID>.json                 the test case executions including their test steps
+//   artifacts/<report ID>/<tce ID>/... the artifacts and review attachments
+////////////////////////////////////////////////////////////
+
+const (
+	reportBundleFormatVersion = 1
+	reportBundleManifestFile  = "manifest.json"
+	reportBundleArchiveFile   = "report.zip"
+)
+
+////////////////////////////////////////////////////////////
+// ReportBundleManifest
+////////////////////////////////////////////////////////////
+
+// Describes the content of a report bundle.
+type ReportBundleManifest struct {
+	FormatVersion int       `json:"formatVersion"`
+	ReportID      int64     `json:"reportId"`
+	ProjectID     int       `json:"projectId,omitzero"`
+	ServerURL     string    `json:"serverUrl"`
+	ExportDate    time.Time `json:"exportDate"`
+	// The converter which is used when the bundle is imported without explicit converter.
+	ConverterID string `json:"converterId,omitempty"`
+	// The path of the original report archive, relative to the bundle directory.
+	ReportFile         string               `json:"reportFile"`
+	TestCaseExecutions []*ReportBundleEntry `json:"testCaseExecutions"`
+}
+
+func (m *ReportBundleManifest) String() string {
+	return fmt.Sprintf("ReportBundleManifest(ReportID: %d, ProjectID: %d, ServerURL: %s, ExportDate: %s, TestCaseExecutions: %d)",
+		m.ReportID, m.ProjectID, m.ServerURL, m.ExportDate.Format(time.RFC3339), len(m.TestCaseExecutions))
+}
+
+////////////////////////////////////////////////////////////
+// ReportBundleEntry
+////////////////////////////////////////////////////////////
+
+// A test case execution in a report bundle. All paths are relative to the bundle directory.
+type ReportBundleEntry struct {
+	TceID     int64    `json:"tceId"`
+	File      string   `json:"file"`
+	Artifacts []string `json:"artifacts,omitempty"`
+}
+
+func (e *ReportBundleEntry) String() string {
+	return fmt.Sprintf("ReportBundleEntry(TceID: %d, File: %s, Artifacts: %d)", e.TceID, e.File, len(e.Artifacts))
+}
+
+////////////////////////////////////////////////////////////
+// ReportExportOptions
+////////////////////////////////////////////////////////////
+
+// Options to export a report into a bundle.
+type ReportExportOptions struct {
+	// The converter to store in the manifest for a later import.
+	ConverterID string
+	// Skip downloading the artifacts and review attachments.
+	SkipArtifacts bool
+	// The number of files which are downloaded at the same time. Defaults to 4.
+	Workers int
+}
+
+func (o *ReportExportOptions) String() string {
+	return fmt.Sprintf("ReportExportOptions(ConverterID: %s, SkipArtifacts: %t, Workers: %d)", o.ConverterID, o.SkipArtifacts, o.Workers)
+}
+
+////////////////////////////////////////////////////////////
+// Export and import
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) DownloadReport(reportId int64) (io.ReadCloser, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/reports/%d/download", reportId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.DoRaw(req)
+	if err != nil {
+		return nil, resp, err
+	}
+	return resp.Body, resp, nil
+}
+
+func (s *ReportManagementService) ExportReport(reportId int64, dir string, options *ReportExportOptions) (*ReportBundleManifest, error) {
+	if options == nil {
+		options = &ReportExportOptions{}
+	}
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve bundle directory: %w", err)
+	}
+	// Only a missing or empty directory may be the target, as it is replaced by the bundle
+	targetExists := false
+	if info, err := os.Stat(dir); err == nil {
+		if !info.IsDir() {
+			return nil, fmt.Errorf("bundle directory %s is not a directory", dir)
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle directory %s: %w", dir, err)
+		}
+		if len(entries) > 0 {
+			return nil, fmt.Errorf("bundle directory %s is not empty", dir)
+		}
+		targetExists = true
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("failed to access bundle directory %s: %w", dir, err)
+	}
+
+	// Write the bundle into a temporary directory which is only moved to the target once it is complete,
+	// so that a failed export does not leave a bundle without manifest behind
+	if err := os.MkdirAll(filepath.Dir(dir), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create directory for %s: %w", dir, err)
+	}
+	tempDir, err := os.MkdirTemp(filepath.Dir(dir), "."+filepath.Base(dir)+"-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary bundle directory: %w", err)
+	}
+	defer os.RemoveAll(tempDir)
+	if err := os.Chmod(tempDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to set permissions of %s: %w", tempDir, err)
+	}
+	manifest, err := s.exportReportTo(reportId, tempDir, options)
+	if err != nil {
+		return nil, err
+	}
+	// An existing empty directory is replaced, os.Remove fails if it is not empty anymore
+	if targetExists {
+		if err := os.Remove(dir); err != nil {
+			return nil, fmt.Errorf("failed to replace bundle directory %s: %w", dir, err)
+		}
+	}
+	if err := os.Rename(tempDir, dir); err != nil {
+		return nil, fmt.Errorf("failed to move bundle to %s: %w", dir, err)
+	}
+	return manifest, nil
+}
+
+// Writes the bundle of the report into the given empty directory.
+func (s *ReportManagementService) exportReportTo(reportId int64, dir string, options *ReportExportOptions) (*ReportBundleManifest, error) {
+	workers := options.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	if err := os.MkdirAll(filepath.Join(dir, "tces"), os.ModePerm); err != nil {
+		return nil, fmt.Errorf("failed to create bundle directory %s: %w", dir, err)
+	}
+	manifest := &ReportBundleManifest{
+		FormatVersion: reportBundleFormatVersion,
+		ReportID:      reportId,
+		ServerURL:     s.client.baseUrl.String(),
+		ExportDate:    time.Now().UTC(),
+		ConverterID:   options.ConverterID,
+		ReportFile:    reportBundleArchiveFile,
+	}
+
+	// Download the original archive
+	if err := s.downloadReportTo(reportId, filepath.Join(dir, reportBundleArchiveFile)); err != nil {
+		return nil, err
+	}
+
+	// Write the test case executions
+	tces, err := s.GetAllTestCaseExecutionsOfReport(reportId)
+	if err != nil {
+		return nil, err
+	}
+	entriesByTceId := map[int64]*ReportBundleEntry{}
+	for _, tce := range tces {
+		if manifest.ProjectID == 0 {
+			manifest.ProjectID = tce.ProjectID
+		}
+		entry := &ReportBundleEntry{TceID: tce.ID, File: fmt.Sprintf("tces/%d.json", tce.ID)}
+		if err := writeJsonFile(filepath.Join(dir, filepath.FromSlash(entry.File)), tce); err != nil {
+			return nil, err
+		}
+		entriesByTceId[tce.ID] = entry
+		manifest.TestCaseExecutions = append(manifest.TestCaseExecutions, entry)
+	}
+
+	// Download the artifacts
+	if !options.SkipArtifacts {
+		results := s.DownloadTestCaseExecutionFiles(tces, filepath.Join(dir, "artifacts"), workers)
+		errs := []error{}
+		for _, result := range results {
+			if result.Error != nil {
+				errs = append(errs, result.Error)
+				continue
+			}
+			relPath, err := filepath.Rel(dir, result.Path)
+			if err != nil {
+				return nil, err
+			}
+			entry := entriesByTceId[result.TceID]
+			entry.Artifacts = append(entry.Artifacts, filepath.ToSlash(relPath))
+		}
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("failed to download %d files: %w", len(errs), errors.Join(errs...))
+		}
+	}
+
+	if err := writeJsonFile(filepath.Join(dir, reportBundleManifestFile), manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (s *ReportManagementService) ImportReport(projectId int, dir string, converterId string) (*TaskRef, *http.Response, error) {
+	manifest, err := ReadReportBundleManifest(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if converterId == "" {
+		converterId = manifest.ConverterID
+	}
+	if converterId == "" {
+		return nil, nil, fmt.Errorf("no converter given and none stored in the bundle %s", dir)
+	}
+	archive, err := os.Open(filepath.Join(dir, filepath.FromSlash(manifest.ReportFile)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open report archive: %w", err)
+	}
+	defer archive.Close()
+	return s.UploadReportZip(projectId, converterId, archive)
+}
+
+// Reads the manifest of the report bundle in the given directory.
+func ReadReportBundleManifest(dir string) (*ReportBundleManifest, error) {
+	fileBytes, err := os.ReadFile(filepath.Join(dir, reportBundleManifestFile))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+	manifest := &ReportBundleManifest{}
+	if err := json.Unmarshal(fileBytes, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	if manifest.FormatVersion > reportBundleFormatVersion {
+		return nil, fmt.Errorf("unsupported bundle format version %d", manifest.FormatVersion)
+	}
+	return manifest, nil
+}
+
+// Downloads the original report archive to the given path.
+func (s *ReportManagementService) downloadReportTo(reportId int64, path string) error {
+	reader, _, err := s.DownloadReport(reportId)
+	if err != nil {
+		return fmt.Errorf("failed to download report %d: %w", reportId, err)
+	}
+	defer reader.Close()
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	if _, err := io.Copy(file, reader); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to download report %d: %w", reportId, err)
+	}
+	return file.Close()
+}
+
+func writeJsonFile(path string, v any) error {
+	fileBytes, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal object: %w", err)
+	}
+	if err := os.WriteFile(path, fileBytes, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_ExportReport(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	archive := []byte("zip content")
+	mux.HandleFunc("/api/report/reports/5/download", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		w.Write(archive)
+	})
+	mux.HandleFunc("/api/report/reports/5", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"tceId": 50}]`))
+	})
+	mux.HandleFunc("/api/report/testCaseExecution/50", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 50, "projectId": 111, "reportId": 5, "testCaseName": "Test", "verdict": "PASSED",
+			"artifacts": [{"id": 1, "filename": "trace.asc", "downloadUrl": "api/report/files/1"}]}`))
+	})
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("trace"))
+	})
+	dir := t.TempDir()
+
+	// Execute
+	manifest, err := client.ReportManagement.ExportReport(5, dir, &ReportExportOptions{ConverterID: "ecu.test"})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(int64(5), manifest.ReportID)
+	assert.Equal(111, manifest.ProjectID, "Project should be taken from the test case executions")
+	assert.Equal([]*ReportBundleEntry{{TceID: 50, File: "tces/50.json", Artifacts: []string{"artifacts/5/50/trace.asc"}}}, manifest.TestCaseExecutions)
+
+	data, _ := os.ReadFile(filepath.Join(dir, "report.zip"))
+	assert.Equal(archive, data, "Original archive should be stored")
+	data, _ = os.ReadFile(filepath.Join(dir, "artifacts", "5", "50", "trace.asc"))
+	assert.Equal("trace", string(data), "Artifacts should be stored")
+	var tce map[string]any
+	data, _ = os.ReadFile(filepath.Join(dir, "tces", "50.json"))
+	assert.NoError(json.Unmarshal(data, &tce))
+	assert.Equal("Test", tce["testCaseName"], "Test case execution should be stored")
+
+	readManifest, err := ReadReportBundleManifest(dir)
+	assert.NoError(err, "Manifest should be readable")
+	assert.Equal("ecu.test", readManifest.ConverterID)
+	assert.True(manifest.ExportDate.Equal(readManifest.ExportDate))
+}
+
+func TestReportManagement_ExportReport_FailedDownload(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/reports/5/download", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("zip content"))
+	})
+	mux.HandleFunc("/api/report/reports/5", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"tceId": 50}]`))
+	})
+	mux.HandleFunc("/api/report/testCaseExecution/50", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 50, "reportId": 5, "artifacts": [{"id": 1, "filename": "trace.asc", "downloadUrl": "api/report/files/1"}]}`))
+	})
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	parentDir := t.TempDir()
+	dir := filepath.Join(parentDir, "bundle")
+
+	// Execute
+	_, err := client.ReportManagement.ExportReport(5, dir, nil)
+
+	// Verify
+	assert.Error(err, "Should return an error")
+	assert.NoDirExists(dir, "Should not leave an incomplete bundle")
+	entries, _ := os.ReadDir(parentDir)
+	assert.Empty(entries, "Should remove the temporary directory")
+}
+
+func TestReportManagement_ExportReport_ExistingFile(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+	filePath := filepath.Join(t.TempDir(), "bundle")
+	assert.NoError(os.WriteFile(filePath, []byte("user data"), 0644))
+
+	// Execute
+	_, err := client.ReportManagement.ExportReport(5, filePath, nil)
+
+	// Verify
+	assert.ErrorContains(err, "is not a directory", "Should not replace a file")
+	content, readErr := os.ReadFile(filePath)
+	assert.NoError(readErr)
+	assert.Equal("user data", string(content), "Should keep the file")
+}
+
+func TestReportManagement_ImportReport(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var receivedBody []byte
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "projectId", "222")
+		verifyHttpQueryParameter(assert, r, "converterId", "ecu.test")
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	dir := t.TempDir()
+	assert.NoError(writeJsonFile(filepath.Join(dir, "manifest.json"), &ReportBundleManifest{FormatVersion: 1, ReportFile: "report.zip", ConverterID: "ecu.test"}))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "report.zip"), []byte("zip content"), 0644))
+
+	// Execute
+	task, _, err := client.ReportManagement.ImportReport(222, dir, "")
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("task1", task.TaskID)
+	assert.Equal([]byte("zip content"), receivedBody, "Original archive should be uploaded as-is")
+}
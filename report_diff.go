@@ -0,0 +1,214 @@
+package gotestguide
+
+import (
+	"fmt"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////
+// TestCaseKey
+////////////////////////////////////////////////////////////
+
+// Identifies a test case across reports.
+type TestCaseKey struct {
+	TestSuiteName string
+	TestCaseName  string
+	ParameterSet  string
+}
+
+func (k TestCaseKey) String() string {
+	name := k.TestCaseName
+	if k.TestSuiteName != "" {
+		name = k.TestSuiteName + " / " + name
+	}
+	if k.ParameterSet != "" {
+		name += " [" + k.ParameterSet + "]"
+	}
+	return name
+}
+
+// Gets the key which identifies the test case of the execution.
+func (t *TestCaseExecution) GetTestCaseKey() TestCaseKey {
+	return TestCaseKey{TestSuiteName: t.TestSuiteName, TestCaseName: t.TestCaseName, ParameterSet: t.ParameterSet}
+}
+
+////////////////////////////////////////////////////////////
+// TestCaseDiff
+////////////////////////////////////////////////////////////
+
+// The comparison of a single test case between two reports.
+type TestCaseDiff struct {
+	Key    TestCaseKey
+	Status TestCaseDiffStatus
+	// The execution in the base report, nil if the test case was added.
+	Base *TestCaseExecution
+	// The execution in the head report, nil if the test case was removed.
+	Head *TestCaseExecution
+}
+
+func (d *TestCaseDiff) String() string {
+	return fmt.Sprintf("TestCaseDiff(Key: %s, Status: %s, Base: %s, Head: %s)", d.Key, d.Status, d.baseVerdict(), d.headVerdict())
+}
+
+func (d *TestCaseDiff) baseVerdict() Verdict {
+	if d.Base == nil {
+		return ""
+	}
+	return d.Base.GetEffectiveVerdict()
+}
+
+func (d *TestCaseDiff) headVerdict() Verdict {
+	if d.Head == nil {
+		return ""
+	}
+	return d.Head.GetEffectiveVerdict()
+}
+
+////////////////////////////////////////////////////////////
+// ReportDiff
+////////////////////////////////////////////////////////////
+
+// The comparison of two reports.
+type ReportDiff struct {
+	BaseReportID int64
+	HeadReportID int64
+	Entries      []*TestCaseDiff
+}
+
+func (d *ReportDiff) String() string {
+	return fmt.Sprintf("ReportDiff(BaseReportID: %d, HeadReportID: %d, NewFailures: %d, Fixed: %d, StillFailing: %d, Added: %d, Removed: %d)",
+		d.BaseReportID, d.HeadReportID, len(d.ByStatus(TEST_CASE_DIFF_STATUS_NEW_FAILURE)), len(d.ByStatus(TEST_CASE_DIFF_STATUS_FIXED)),
+		len(d.ByStatus(TEST_CASE_DIFF_STATUS_STILL_FAILING)), len(d.ByStatus(TEST_CASE_DIFF_STATUS_ADDED)), len(d.ByStatus(TEST_CASE_DIFF_STATUS_REMOVED)))
+}
+
+// Gets all entries with the given status.
+func (d *ReportDiff) ByStatus(status TestCaseDiffStatus) []*TestCaseDiff {
+	ret := []*TestCaseDiff{}
+	for _, entry := range d.Entries {
+		if entry.Status == status {
+			ret = append(ret, entry)
+		}
+	}
+	return ret
+}
+
+// The sections of the Markdown summary in the order they are shown.
+var reportDiffSections = []struct {
+	status TestCaseDiffStatus
+	title  string
+}{
+	{TEST_CASE_DIFF_STATUS_NEW_FAILURE, "New failures"},
+	{TEST_CASE_DIFF_STATUS_FIXED, "Fixed"},
+	{TEST_CASE_DIFF_STATUS_STILL_FAILING, "Still failing"},
+	{TEST_CASE_DIFF_STATUS_CHANGED_VERDICT, "Changed verdict"},
+	{TEST_CASE_DIFF_STATUS_ADDED, "Added"},
+	{TEST_CASE_DIFF_STATUS_REMOVED, "Removed"},
+}
+
+// Creates a Markdown summary of the comparison, e.g. for pull request comments.
+func (d *ReportDiff) Markdown() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "## Comparison of report %d with report %d\n\n", d.HeadReportID, d.BaseReportID)
+	sb.WriteString("| Status | Count |\n| --- | --- |\n")
+	for _, section := range reportDiffSections {
+		fmt.Fprintf(&sb, "| %s | %d |\n", section.title, len(d.ByStatus(section.status)))
+	}
+	fmt.Fprintf(&sb, "| Unchanged | %d |\n", len(d.ByStatus(TEST_CASE_DIFF_STATUS_UNCHANGED)))
+	for _, section := range reportDiffSections {
+		entries := d.ByStatus(section.status)
+		if len(entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section.title)
+		for _, entry := range entries {
+			switch entry.Status {
+			case TEST_CASE_DIFF_STATUS_ADDED:
+				fmt.Fprintf(&sb, "- `%s`: %s\n", entry.Key, entry.headVerdict())
+			case TEST_CASE_DIFF_STATUS_REMOVED:
+				fmt.Fprintf(&sb, "- `%s`: was %s\n", entry.Key, entry.baseVerdict())
+			default:
+				fmt.Fprintf(&sb, "- `%s`: %s → %s\n", entry.Key, entry.baseVerdict(), entry.headVerdict())
+			}
+		}
+	}
+	return sb.String()
+}
+
+////////////////////////////////////////////////////////////
+// Comparison
+////////////////////////////////////////////////////////////
+
+// Compares the test case executions of two reports.
+// Executions are matched by test suite, test case name and parameter set.
+// If a test case is executed multiple times in a report, the executions are matched in order.
+func DiffTestCaseExecutions(base []*TestCaseExecution, head []*TestCaseExecution) *ReportDiff {
+	diff := &ReportDiff{}
+	baseByKey := map[TestCaseKey][]*TestCaseExecution{}
+	for _, tce := range base {
+		key := tce.GetTestCaseKey()
+		baseByKey[key] = append(baseByKey[key], tce)
+	}
+	for _, headTce := range head {
+		key := headTce.GetTestCaseKey()
+		entry := &TestCaseDiff{Key: key, Head: headTce}
+		if candidates := baseByKey[key]; len(candidates) > 0 {
+			entry.Base = candidates[0]
+			baseByKey[key] = candidates[1:]
+		}
+		entry.Status = classifyTestCaseDiff(entry.Base, entry.Head)
+		diff.Entries = append(diff.Entries, entry)
+	}
+	// Everything left in the base was removed
+	for _, baseTce := range base {
+		key := baseTce.GetTestCaseKey()
+		for _, remaining := range baseByKey[key] {
+			if remaining == baseTce {
+				diff.Entries = append(diff.Entries, &TestCaseDiff{Key: key, Status: TEST_CASE_DIFF_STATUS_REMOVED, Base: baseTce})
+			}
+		}
+	}
+	return diff
+}
+
+func classifyTestCaseDiff(base *TestCaseExecution, head *TestCaseExecution) TestCaseDiffStatus {
+	if base == nil {
+		return TEST_CASE_DIFF_STATUS_ADDED
+	}
+	if head == nil {
+		return TEST_CASE_DIFF_STATUS_REMOVED
+	}
+	baseVerdict := base.GetEffectiveVerdict()
+	headVerdict := head.GetEffectiveVerdict()
+	switch {
+	case !isFailingVerdict(baseVerdict) && isFailingVerdict(headVerdict):
+		return TEST_CASE_DIFF_STATUS_NEW_FAILURE
+	case isFailingVerdict(baseVerdict) && isFailingVerdict(headVerdict):
+		return TEST_CASE_DIFF_STATUS_STILL_FAILING
+	case isFailingVerdict(baseVerdict) && headVerdict == VERDICT_PASSED:
+		return TEST_CASE_DIFF_STATUS_FIXED
+	case baseVerdict != headVerdict:
+		return TEST_CASE_DIFF_STATUS_CHANGED_VERDICT
+	}
+	return TEST_CASE_DIFF_STATUS_UNCHANGED
+}
+
+// Checks if the verdict is a failure (FAILED or ERROR).
+func isFailingVerdict(verdict Verdict) bool {
+	return verdict == VERDICT_FAILED || verdict == VERDICT_ERROR
+}
+
+// Compares the test case executions of two reports.
+func (s *ReportManagementService) CompareReports(baseReportId int64, headReportId int64) (*ReportDiff, error) {
+	base, err := s.GetAllTestCaseExecutionsOfReport(baseReportId)
+	if err != nil {
+		return nil, err
+	}
+	head, err := s.GetAllTestCaseExecutionsOfReport(headReportId)
+	if err != nil {
+		return nil, err
+	}
+	diff := DiffTestCaseExecutions(base, head)
+	diff.BaseReportID = baseReportId
+	diff.HeadReportID = headReportId
+	return diff, nil
+}
@@ -0,0 +1,121 @@
+package gotestguide
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTestCaseExecutions(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	newTce := func(name string, parameterSet string, verdict Verdict) *TestCaseExecution {
+		return &TestCaseExecution{TestSuiteName: "Suite", TestCaseName: name, ParameterSet: parameterSet, Verdict: verdict}
+	}
+	base := []*TestCaseExecution{
+		newTce("Regression", "", VERDICT_PASSED),
+		newTce("Fixed", "", VERDICT_FAILED),
+		newTce("Broken", "", VERDICT_ERROR),
+		newTce("Flaky", "", VERDICT_PASSED),
+		newTce("Stable", "A", VERDICT_PASSED),
+		newTce("Stable", "B", VERDICT_PASSED),
+		newTce("Removed", "", VERDICT_PASSED),
+	}
+	head := []*TestCaseExecution{
+		newTce("Regression", "", VERDICT_FAILED),
+		newTce("Fixed", "", VERDICT_PASSED),
+		newTce("Broken", "", VERDICT_FAILED),
+		newTce("Flaky", "", VERDICT_INCONCLUSIVE),
+		newTce("Stable", "B", VERDICT_PASSED),
+		newTce("Stable", "A", VERDICT_PASSED),
+		newTce("Added", "", VERDICT_PASSED),
+	}
+	// The review of the head overrides the verdict
+	head[2].EffectiveVerdict = VERDICT_PASSED
+
+	// Execute
+	diff := DiffTestCaseExecutions(base, head)
+
+	// Verify
+	statuses := map[string]TestCaseDiffStatus{}
+	for _, entry := range diff.Entries {
+		statuses[entry.Key.String()] = entry.Status
+	}
+	assert.Equal(map[string]TestCaseDiffStatus{
+		"Suite / Regression": TEST_CASE_DIFF_STATUS_NEW_FAILURE,
+		"Suite / Fixed":      TEST_CASE_DIFF_STATUS_FIXED,
+		"Suite / Broken":     TEST_CASE_DIFF_STATUS_FIXED,
+		"Suite / Flaky":      TEST_CASE_DIFF_STATUS_CHANGED_VERDICT,
+		"Suite / Stable [A]": TEST_CASE_DIFF_STATUS_UNCHANGED,
+		"Suite / Stable [B]": TEST_CASE_DIFF_STATUS_UNCHANGED,
+		"Suite / Added":      TEST_CASE_DIFF_STATUS_ADDED,
+		"Suite / Removed":    TEST_CASE_DIFF_STATUS_REMOVED,
+	}, statuses, "Statuses should match expected values")
+	assert.Len(diff.Entries, 8, "Each test case should be listed once")
+
+	markdown := diff.Markdown()
+	assert.Contains(markdown, "| New failures | 1 |")
+	assert.Contains(markdown, "| Fixed | 2 |")
+	assert.Contains(markdown, "- `Suite / Regression`: PASSED → FAILED")
+	assert.Contains(markdown, "- `Suite / Removed`: was PASSED")
+	assert.NotContains(markdown, "### Still failing", "Empty sections should be omitted")
+}
+
+func TestDiffTestCaseExecutions_RepeatedExecutions(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	base := []*TestCaseExecution{
+		{TestCaseName: "Repeated", Verdict: VERDICT_PASSED},
+		{TestCaseName: "Repeated", Verdict: VERDICT_FAILED},
+	}
+	head := []*TestCaseExecution{
+		{TestCaseName: "Repeated", Verdict: VERDICT_PASSED},
+	}
+
+	// Execute
+	diff := DiffTestCaseExecutions(base, head)
+
+	// Verify
+	assert.Len(diff.Entries, 2)
+	assert.Equal(TEST_CASE_DIFF_STATUS_UNCHANGED, diff.Entries[0].Status, "Executions should be matched in order")
+	assert.Equal(TEST_CASE_DIFF_STATUS_REMOVED, diff.Entries[1].Status, "Surplus base executions should be removed")
+	assert.Same(base[1], diff.Entries[1].Base)
+}
+
+func TestReportManagement_CompareReports(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	reports := map[int64][]string{
+		1: {"PASSED", "FAILED"},
+		2: {"FAILED", "PASSED"},
+	}
+	for reportId, verdicts := range reports {
+		mux.HandleFunc(fmt.Sprintf("/api/report/reports/%d", reportId), func(w http.ResponseWriter, r *http.Request) {
+			verifyHttpMethod(assert, r, http.MethodGet)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `[{"tceId": %d}, {"tceId": %d}]`, reportId*10, reportId*10+1)
+		})
+		for i, verdict := range verdicts {
+			mux.HandleFunc(fmt.Sprintf("/api/report/testCaseExecution/%d", reportId*10+int64(i)), func(w http.ResponseWriter, r *http.Request) {
+				verifyHttpMethod(assert, r, http.MethodGet)
+				w.WriteHeader(http.StatusOK)
+				fmt.Fprintf(w, `{"id": %d, "reportId": %d, "testCaseName": "Test %d", "verdict": "%s"}`, reportId*10+int64(i), reportId, i, verdict)
+			})
+		}
+	}
+
+	// Execute
+	diff, err := client.ReportManagement.CompareReports(1, 2)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(int64(1), diff.BaseReportID)
+	assert.Equal(int64(2), diff.HeadReportID)
+	assert.Len(diff.ByStatus(TEST_CASE_DIFF_STATUS_NEW_FAILURE), 1)
+	assert.Equal("Test 0", diff.ByStatus(TEST_CASE_DIFF_STATUS_NEW_FAILURE)[0].Key.TestCaseName)
+	assert.Len(diff.ByStatus(TEST_CASE_DIFF_STATUS_FIXED), 1)
+	assert.Equal(int64(21), diff.ByStatus(TEST_CASE_DIFF_STATUS_FIXED)[0].Head.ID)
+}
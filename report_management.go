@@ -3,15 +3,18 @@ package gotestguide
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
+	"io/fs"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -21,30 +24,124 @@ type (
 		GetConverters() ([]*Converter, *http.Response, error)
 		// Upload a new report.
 		UploadReport(projectId int, converterId string, reportPath string) (*TaskRef, *http.Response, error)
+		// Upload a new report consisting of multiple files. The paths inside the archive are relative to baseDir.
+		UploadReportFiles(projectId int, converterId string, baseDir string, filePaths []string) (*TaskRef, *http.Response, error)
+		// Upload a new report with the files of a directory, preserving the relative paths.
+		UploadReportDirectory(projectId int, converterId string, dirPath string, options *ReportArchiveOptions) (*TaskRef, *http.Response, error)
+		// Upload a new report with the files of a file system, preserving the relative paths.
+		UploadReportFS(projectId int, converterId string, fsys fs.FS, options *ReportArchiveOptions) (*TaskRef, *http.Response, error)
 		// Uploads a new report from the given objects.
 		UploadReportTyped(projectId int, report *UploadReport) (*TaskRef, *http.Response, error)
+		// Uploads a new report from the given objects together with the files it references, which are read relative to baseDir.
+		UploadReportTypedWithFiles(projectId int, report *UploadReport, baseDir string) (*TaskRef, *http.Response, error)
+		// Upload a new report from a reader. The content is added to the archive with the given file name.
+		UploadReportReader(projectId int, converterId string, fileName string, reader io.Reader) (*TaskRef, *http.Response, error)
+		// Upload an existing zip archive as-is.
+		UploadReportZip(projectId int, converterId string, zipReader io.Reader) (*TaskRef, *http.Response, error)
 		// Delete the report with the given report ID (ATX ID).
 		DeleteReport(reportId int64) (*TaskRef, *http.Response, error)
 		// Retrieve all test case executions for the supplied report ID (ATX ID).
 		GetTestCaseExecutions(reportId int64) ([]*TestCaseExecutionLink, *http.Response, error)
 		// Retrieve details about a specific test case execution.
 		GetTestCaseExecution(tceId int64) (*TestCaseExecution, *http.Response, error)
+		// Get the details of all test case executions of a report.
+		GetAllTestCaseExecutionsOfReport(reportId int64) ([]*TestCaseExecution, error)
 		// Retrieve current state of report upload.
 		GetUploadStatus(taskId string) (*UploadStatus, *http.Response, error)
 		// Retrieve delete task status.
 		GetDeleteStatus(taskId string) (*DeleteStatus, *http.Response, error)
+		// Wait until the upload task is done. A timeout of zero waits forever.
+		WaitForUpload(taskId string, pollInterval time.Duration, timeout time.Duration) (*UploadStatus, *http.Response, error)
+		// Wait until the upload task is done or the context is canceled. A timeout of zero waits forever.
+		WaitForUploadContext(ctx context.Context, taskId string, pollInterval time.Duration, timeout time.Duration) (*UploadStatus, *http.Response, error)
+		// Upload many reports concurrently and wait until all of them are processed.
+		BulkUpload(projectId int, items []*BulkUploadItem, options *BulkUploadOptions) (*BulkUploadSummary, error)
 		// Provides metadata for uploaded reports.
 		GetHistory(projectId int, startDate time.Time, endTime time.Time, offset int, limit int) ([]*ReportHistoryItem, *http.Response, error)
 		// Adds an artifact to an existing test case execution.
 		AddArtifact(tceId int64, filePath string, comment string, category string) (*http.Response, error)
+		// Adds files and the files of directories to many test case executions, skipping files which are already attached.
+		// Files of directories are named by their path relative to the directory.
+		AddArtifacts(tces []*TestCaseExecution, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error)
+		// Adds files and the files of directories to all test case executions of a report.
+		AddArtifactsToReport(reportId int64, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error)
+		// Adds files and the files of directories to all test case executions matching the filter parameters.
+		AddArtifactsByFilter(projectId int, filter *FilterParameters, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error)
 		// Retrieve project filters.
 		GetFilters(projectId int, offset *int, limit *int) ([]*FilterInformation, *http.Response, error)
 		// Retrieve a specific project filter including its parameters.
 		GetFilter(filterId int64) (*Filter, *http.Response, error)
+		// Create a new project filter.
+		CreateFilter(projectId int, filter *Filter) (*Filter, *http.Response, error)
+		// Update an existing project filter identified by its filter ID.
+		UpdateFilter(filter *Filter) (*Filter, *http.Response, error)
+		// Delete a project filter.
+		DeleteFilter(filterId int64) (*http.Response, error)
 		// Get test case executions matching the filter parameters.
 		GetTestCaseExecutionsByFilter(projectId int, offset *int, limit *int, filter *FilterParameters) ([]*TestCaseExecution, *http.Response, error)
 		// Get test case executions of the specified project filter.
 		GetTestCaseExecutionsByProjectFilter(filterId int64, offset *int, limit *int) ([]*TestCaseExecution, *http.Response, error)
+		// Get all test case executions matching the filter parameters by paging through the results.
+		GetAllTestCaseExecutionsByFilter(projectId int, filter *FilterParameters) ([]*TestCaseExecution, error)
+		// Get all test case executions of the specified project filter by paging through the results.
+		GetAllTestCaseExecutionsByProjectFilter(filterId int64) ([]*TestCaseExecution, error)
+		// Create a review for a test case execution.
+		CreateReview(tceId int64, review *Review) (*Review, *http.Response, error)
+		// Retrieve the review history of a test case execution.
+		GetReviews(tceId int64) ([]*Review, *http.Response, error)
+		// Create the same review for all test case executions matching the filter parameters.
+		CreateReviewsByFilter(projectId int, filter *FilterParameters, review *Review, workers int) ([]*BulkReviewResult, error)
+		// Add or replace attributes of a test case execution.
+		SetAttributes(tceId int64, attributes []*Attribute) ([]*Attribute, *http.Response, error)
+		// Remove an attribute from a test case execution.
+		DeleteAttribute(tceId int64, key string) (*http.Response, error)
+		// Apply the same attribute changes to many test case executions concurrently.
+		UpdateAttributes(tceIds []int64, update *AttributeUpdate, workers int) []*BulkAttributeResult
+		// Apply the same attribute changes to all test case executions matching the filter parameters.
+		UpdateAttributesByFilter(projectId int, filter *FilterParameters, update *AttributeUpdate, workers int) ([]*BulkAttributeResult, error)
+		// Compare the test case executions of two reports and list regressions and fixes.
+		CompareReports(baseReportId int64, headReportId int64) (*ReportDiff, error)
+		// Analyze the flakiness of the tests matching the filter parameters in the given time window.
+		AnalyzeFlakiness(projectId int, filter *FilterParameters, from time.Time, to time.Time) ([]*FlakinessResult, error)
+		// Compute the statistics and a time series of the test case executions matching the filter parameters.
+		GetStatistics(projectId int, filter *FilterParameters, interval StatisticsInterval) (*TestCaseExecutionStatistics, error)
+		// Download a file like an artifact or an attachment. The content is verified against the file hash while reading.
+		// The caller must close the returned reader.
+		DownloadFile(file *FileReference) (io.ReadCloser, *http.Response, error)
+		// Download a file to the given path, resuming a previously interrupted download and verifying the file hash.
+		DownloadFileTo(file *FileReference, path string) error
+		// Download the artifacts and review attachments of test case executions into a directory tree.
+		DownloadTestCaseExecutionFiles(tces []*TestCaseExecution, dir string, workers int) []*ArtifactDownloadResult
+		// Download the original uploaded archive of a report. The caller must close the returned reader.
+		DownloadReport(reportId int64) (io.ReadCloser, *http.Response, error)
+		// Export a report with its original archive, test case executions and files into a bundle directory.
+		ExportReport(reportId int64, dir string, options *ReportExportOptions) (*ReportBundleManifest, error)
+		// Upload the original archive of an exported report bundle. Uses the converter of the bundle if converterId is empty.
+		ImportReport(projectId int, dir string, converterId string) (*TaskRef, *http.Response, error)
+		// Wait until a delete task is done. Returns an error if the task failed or the timeout (if greater than zero) elapsed.
+		WaitForDelete(taskId string, pollInterval time.Duration, timeout time.Duration) (*DeleteStatus, *http.Response, error)
+		// Delete the reports of the history which match the options and are not kept by the keep rules.
+		PruneReports(projectId int, options *PruneOptions) ([]*PruneResult, error)
+		// Get all releases of a project.
+		GetReleases(projectId int) ([]*Release, *http.Response, error)
+		// Get a single release.
+		GetRelease(releaseId int64) (*Release, *http.Response, error)
+		// Create a new release in a project.
+		CreateRelease(projectId int, release *Release) (*Release, *http.Response, error)
+		// Update an existing release identified by its ID.
+		UpdateRelease(release *Release) (*Release, *http.Response, error)
+		// Close a release so no more test case executions can be assigned.
+		CloseRelease(releaseId int64) (*Release, *http.Response, error)
+		// Assign test case executions to a release.
+		AssignTestCaseExecutionsToRelease(releaseId int64, tceIds []int64) (*http.Response, error)
+		// Remove test case executions from a release.
+		UnassignTestCaseExecutionsFromRelease(releaseId int64, tceIds []int64) (*http.Response, error)
+		// Assign all test case executions of reports to a release.
+		AssignReportsToRelease(releaseId int64, reportIds []int64) (*http.Response, error)
+		// Remove all test case executions of reports from a release.
+		UnassignReportsFromRelease(releaseId int64, reportIds []int64) (*http.Response, error)
+		// Get the progress of a release.
+		GetReleaseProgress(releaseId int64) (*ReleaseProgress, *http.Response, error)
 	}
 	ReportManagementService struct {
 		client *Client
@@ -67,31 +164,36 @@ func (s *ReportManagementService) GetConverters() ([]*Converter, *http.Response,
 }
 
 func (s *ReportManagementService) UploadReport(projectId int, converterId string, reportPath string) (*TaskRef, *http.Response, error) {
-	// Create a buffer to write our archive to.
-	buf := new(bytes.Buffer)
-
-	// Create a new zip archive.
-	w := zip.NewWriter(buf)
-	// Add the report file to the zip archive.
-	fileContent, err := os.ReadFile(reportPath)
+	archive, err := createArchiveFromFiles("", []string{reportPath})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to read file %s: %w", reportPath, err)
+		return nil, nil, err
 	}
-	f, err := w.Create(filepath.Base(reportPath))
+	return s.uploadArchive(projectId, converterId, bytes.NewReader(archive))
+}
+
+func (s *ReportManagementService) UploadReportFiles(projectId int, converterId string, baseDir string, filePaths []string) (*TaskRef, *http.Response, error) {
+	archive, err := createArchiveFromFiles(baseDir, filePaths)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create zip entry for file %s: %w", reportPath, err)
+		return nil, nil, err
 	}
-	_, err = f.Write(fileContent)
+	return s.uploadArchive(projectId, converterId, bytes.NewReader(archive))
+}
+
+func (s *ReportManagementService) UploadReportDirectory(projectId int, converterId string, dirPath string, options *ReportArchiveOptions) (*TaskRef, *http.Response, error) {
+	return s.UploadReportFS(projectId, converterId, os.DirFS(dirPath), options)
+}
+
+func (s *ReportManagementService) UploadReportFS(projectId int, converterId string, fsys fs.FS, options *ReportArchiveOptions) (*TaskRef, *http.Response, error) {
+	archive, err := createArchiveFromFS(fsys, options)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to write file %s to zip: %w", reportPath, err)
-	}
-	// Close the zip
-	if err := w.Close(); err != nil {
-		return nil, nil, fmt.Errorf("failed to close zip writer: %w", err)
+		return nil, nil, err
 	}
+	return s.uploadArchive(projectId, converterId, bytes.NewReader(archive))
+}
 
-	// Send the request
-	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("api/report/reports?projectId=%d&converterId=%s", projectId, converterId), bytes.NewReader(buf.Bytes()))
+// Uploads the zipped report archive.
+func (s *ReportManagementService) uploadArchive(projectId int, converterId string, archive io.Reader) (*TaskRef, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("api/report/reports?projectId=%d&converterId=%s", projectId, converterId), archive)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -109,18 +211,34 @@ func (s *ReportManagementService) UploadReportTyped(projectId int, report *Uploa
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to marshal report: %w", err)
 	}
-	file, err := os.CreateTemp("", "report-*.json")
+	return s.UploadReportReader(projectId, "json2atx", typedReportFileName, bytes.NewReader(reportBytes))
+}
+
+func (s *ReportManagementService) UploadReportTypedWithFiles(projectId int, report *UploadReport, baseDir string) (*TaskRef, *http.Response, error) {
+	archive, err := createTypedReportArchive(report, baseDir)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create temp file: %w", err)
+		return nil, nil, err
 	}
-	defer os.Remove(file.Name())
-	if _, err := file.Write(reportBytes); err != nil {
-		return nil, nil, fmt.Errorf("failed to write report to temp file: %w", err)
+	return s.uploadArchive(projectId, "json2atx", bytes.NewReader(archive))
+}
+
+func (s *ReportManagementService) UploadReportReader(projectId int, converterId string, fileName string, reader io.Reader) (*TaskRef, *http.Response, error) {
+	buf := new(bytes.Buffer)
+	w := zip.NewWriter(buf)
+	err := addFileToArchive(w, fileName, func() (io.ReadCloser, error) {
+		return io.NopCloser(reader), nil
+	})
+	if err != nil {
+		return nil, nil, err
 	}
-	if err := file.Close(); err != nil {
-		return nil, nil, fmt.Errorf("failed to close temp file: %w", err)
+	if err := w.Close(); err != nil {
+		return nil, nil, fmt.Errorf("failed to close zip writer: %w", err)
 	}
-	return s.UploadReport(projectId, "json2atx", file.Name())
+	return s.uploadArchive(projectId, converterId, buf)
+}
+
+func (s *ReportManagementService) UploadReportZip(projectId int, converterId string, zipReader io.Reader) (*TaskRef, *http.Response, error) {
+	return s.uploadArchive(projectId, converterId, zipReader)
 }
 
 func (s *ReportManagementService) DeleteReport(reportId int64) (*TaskRef, *http.Response, error) {
@@ -177,11 +295,37 @@ func (s *ReportManagementService) GetTestCaseExecution(tceId int64) (*TestCaseEx
 	return responseObject, resp, nil
 }
 
+// The number of test case executions of a report which are fetched at the same time.
+const reportTestCaseExecutionWorkers = 4
+
+func (s *ReportManagementService) GetAllTestCaseExecutionsOfReport(reportId int64) ([]*TestCaseExecution, error) {
+	links, _, err := s.GetTestCaseExecutions(reportId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test case executions of report %d: %w", reportId, err)
+	}
+	tces := make([]*TestCaseExecution, len(links))
+	errs := make([]error, len(links))
+	forEachParallel(len(links), reportTestCaseExecutionWorkers, func(i int) {
+		tces[i], _, errs[i] = s.GetTestCaseExecution(links[i].TceID)
+	})
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test case execution %d: %w", links[i].TceID, err)
+		}
+	}
+	return tces, nil
+}
+
 func (s *ReportManagementService) GetUploadStatus(taskId string) (*UploadStatus, *http.Response, error) {
+	return s.getUploadStatus(context.Background(), taskId)
+}
+
+func (s *ReportManagementService) getUploadStatus(ctx context.Context, taskId string) (*UploadStatus, *http.Response, error) {
 	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/reports/uploadstatus/%s", taskId), nil)
 	if err != nil {
 		return nil, nil, err
 	}
+	req = req.WithContext(ctx)
 	var responseObject = &UploadStatus{}
 	resp, err := s.client.Do(req, &responseObject)
 	if err != nil {
@@ -203,7 +347,43 @@ func (s *ReportManagementService) GetDeleteStatus(taskId string) (*DeleteStatus,
 	return responseObject, resp, nil
 }
 
+func (s *ReportManagementService) WaitForUpload(taskId string, pollInterval time.Duration, timeout time.Duration) (*UploadStatus, *http.Response, error) {
+	return s.WaitForUploadContext(context.Background(), taskId, pollInterval, timeout)
+}
+
+func (s *ReportManagementService) WaitForUploadContext(ctx context.Context, taskId string, pollInterval time.Duration, timeout time.Duration) (*UploadStatus, *http.Response, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		status, resp, err := s.getUploadStatus(ctx, taskId)
+		if err != nil {
+			return nil, resp, err
+		}
+		if status.IsDone() {
+			if !status.IsSuccessful() {
+				return status, resp, fmt.Errorf("upload task %s failed: %s", taskId, strings.Join(status.UploadResult.ResultMessages, "; "))
+			}
+			return status, resp, nil
+		}
+		if !deadline.IsZero() && time.Now().Add(pollInterval).After(deadline) {
+			return status, resp, fmt.Errorf("timed out waiting for upload task %s", taskId)
+		}
+		select {
+		case <-ctx.Done():
+			return status, resp, fmt.Errorf("stopped waiting for upload task %s: %w", taskId, ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
 func (s *ReportManagementService) AddArtifact(tceId int64, filePath string, comment string, category string) (*http.Response, error) {
+	return s.addArtifactAs(tceId, filePath, filepath.Base(filePath), comment, category)
+}
+
+// Adds the file as artifact with the given file name.
+func (s *ReportManagementService) addArtifactAs(tceId int64, filePath string, name string, comment string, category string) (*http.Response, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
@@ -215,7 +395,7 @@ func (s *ReportManagementService) AddArtifact(tceId int64, filePath string, comm
 	writer := multipart.NewWriter(body)
 
 	// Create the file part
-	part, err := writer.CreateFormFile("file", filepath.Base(file.Name()))
+	part, err := writer.CreateFormFile("file", name)
 	if err != nil {
 		return nil, err
 	}
@@ -284,6 +464,60 @@ func (s *ReportManagementService) GetFilter(filterId int64) (*Filter, *http.Resp
 	return responseObject, resp, nil
 }
 
+func (s *ReportManagementService) CreateFilter(projectId int, filter *Filter) (*Filter, *http.Response, error) {
+	// Prepare the body
+	bodyBytes, err := json.Marshal(filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	// Prepare the request
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("api/report/filters?projectId=%d", projectId), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	var responseObject = &Filter{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) UpdateFilter(filter *Filter) (*Filter, *http.Response, error) {
+	// Prepare the body
+	bodyBytes, err := json.Marshal(filter)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	// Prepare the request
+	req, err := s.client.NewRequest(http.MethodPut, fmt.Sprintf("api/report/filters/%d", filter.FilterId), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	var responseObject = &Filter{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) DeleteFilter(filterId int64) (*http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("api/report/filters/%d", filterId), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(req, nil)
+}
+
 func (s *ReportManagementService) GetTestCaseExecutionsByFilter(projectId int, offset *int, limit *int, filter *FilterParameters) ([]*TestCaseExecution, *http.Response, error) {
 	reqUrl := fmt.Sprintf("api/report/testCaseExecutions/filter?projectId=%d", projectId)
 	if offset != nil {
@@ -340,3 +574,33 @@ func (s *ReportManagementService) GetTestCaseExecutionsByProjectFilter(filterId
 	}
 	return responseObject, resp, nil
 }
+
+// The number of test case executions which are fetched per request when paging through all results.
+const testCaseExecutionPageSize = 100
+
+func (s *ReportManagementService) GetAllTestCaseExecutionsByFilter(projectId int, filter *FilterParameters) ([]*TestCaseExecution, error) {
+	return getAllTestCaseExecutions(func(offset, limit int) ([]*TestCaseExecution, *http.Response, error) {
+		return s.GetTestCaseExecutionsByFilter(projectId, &offset, &limit, filter)
+	})
+}
+
+func (s *ReportManagementService) GetAllTestCaseExecutionsByProjectFilter(filterId int64) ([]*TestCaseExecution, error) {
+	return getAllTestCaseExecutions(func(offset, limit int) ([]*TestCaseExecution, *http.Response, error) {
+		return s.GetTestCaseExecutionsByProjectFilter(filterId, &offset, &limit)
+	})
+}
+
+// Fetches pages until a page is not full anymore.
+func getAllTestCaseExecutions(getPage func(offset, limit int) ([]*TestCaseExecution, *http.Response, error)) ([]*TestCaseExecution, error) {
+	ret := []*TestCaseExecution{}
+	for offset := 0; ; offset += testCaseExecutionPageSize {
+		page, _, err := getPage(offset, testCaseExecutionPageSize)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, page...)
+		if len(page) < testCaseExecutionPageSize {
+			return ret, nil
+		}
+	}
+}
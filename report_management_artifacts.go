@@ -0,0 +1,183 @@
+package gotestguide
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////
+// AddArtifactsOptions
+////////////////////////////////////////////////////////////
+
+// Options to add artifacts to many test case executions.
+type AddArtifactsOptions struct {
+	// Optional comment for all added artifacts.
+	Comment string
+	// Optional category for all added artifacts.
+	Category string
+	// Number of test case executions which are processed at the same time. Defaults to 4.
+	Workers int
+	// Also add files which are already attached to a test case execution or are contained multiple times in the given paths.
+	KeepDuplicates bool
+}
+
+func (o *AddArtifactsOptions) String() string {
+	return fmt.Sprintf("AddArtifactsOptions(Comment: %s, Category: %s, Workers: %d, KeepDuplicates: %t)", o.Comment, o.Category, o.Workers, o.KeepDuplicates)
+}
+
+////////////////////////////////////////////////////////////
+// AddArtifactsResult
+////////////////////////////////////////////////////////////
+
+// The result of adding artifacts to a single test case execution.
+type AddArtifactsResult struct {
+	TceID int64
+	// The paths of the files which were added.
+	Added []string
+	// The paths of the files which were skipped as they are already attached.
+	Skipped []string
+	Error   error
+}
+
+func (r *AddArtifactsResult) String() string {
+	return fmt.Sprintf("AddArtifactsResult(TceID: %d, Added: %d, Skipped: %d, Error: %v)", r.TceID, len(r.Added), len(r.Skipped), r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// Bulk artifacts
+////////////////////////////////////////////////////////////
+
+// A local file with its hashes to detect identical files.
+type artifactFile struct {
+	path string
+	// The file name of the artifact.
+	name   string
+	hashes []string
+}
+
+func (s *ReportManagementService) AddArtifacts(tces []*TestCaseExecution, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error) {
+	if options == nil {
+		options = &AddArtifactsOptions{}
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	files, err := collectArtifactFiles(paths, !options.KeepDuplicates)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*AddArtifactsResult, len(tces))
+	forEachParallel(len(tces), workers, func(i int) {
+		tce := tces[i]
+		result := &AddArtifactsResult{TceID: tce.ID}
+		results[i] = result
+		existingHashes := map[string]bool{}
+		for _, artifact := range tce.Artifacts {
+			existingHashes[strings.ToLower(artifact.FileHash)] = true
+		}
+		for _, file := range files {
+			if !options.KeepDuplicates && containsAnyHash(existingHashes, file.hashes) {
+				result.Skipped = append(result.Skipped, file.path)
+				continue
+			}
+			if _, err := s.addArtifactAs(tce.ID, file.path, file.name, options.Comment, options.Category); err != nil {
+				result.Error = fmt.Errorf("failed to add %s: %w", file.path, err)
+				return
+			}
+			result.Added = append(result.Added, file.path)
+		}
+	})
+	return results, nil
+}
+
+func (s *ReportManagementService) AddArtifactsToReport(reportId int64, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error) {
+	tces, err := s.GetAllTestCaseExecutionsOfReport(reportId)
+	if err != nil {
+		return nil, err
+	}
+	return s.AddArtifacts(tces, paths, options)
+}
+
+func (s *ReportManagementService) AddArtifactsByFilter(projectId int, filter *FilterParameters, paths []string, options *AddArtifactsOptions) ([]*AddArtifactsResult, error) {
+	tces, err := s.GetAllTestCaseExecutionsByFilter(projectId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test case executions: %w", err)
+	}
+	return s.AddArtifacts(tces, paths, options)
+}
+
+// Expands the paths into the regular files they contain (directories recursively) and hashes them.
+// Files with identical content are only returned once if dedup is set.
+// Files are named by their path relative to the given directory (e.g. "a/log.txt") or by their file name if given directly,
+// different files which would get the same name are rejected.
+func collectArtifactFiles(paths []string, dedup bool) ([]*artifactFile, error) {
+	files := []*artifactFile{}
+	seenHashes := map[string]bool{}
+	filesByName := map[string]*artifactFile{}
+	addFile := func(path string, name string) error {
+		// The server may use any of the hashes for the attached files
+		hashes, err := calculateFileHashes(path, fileHashFuncs...)
+		if err != nil {
+			return fmt.Errorf("failed to hash file %s: %w", path, err)
+		}
+		if dedup {
+			if seenHashes[hashes[0]] {
+				return nil
+			}
+			seenHashes[hashes[0]] = true
+		}
+		if other, exists := filesByName[name]; exists && other.hashes[0] != hashes[0] {
+			return fmt.Errorf("files %s and %s would both be added as %s", other.path, path, name)
+		}
+		file := &artifactFile{path: path, name: name, hashes: hashes}
+		filesByName[name] = file
+		files = append(files, file)
+		return nil
+	}
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to access %s: %w", path, err)
+		}
+		if !info.IsDir() {
+			if err := addFile(path, filepath.Base(path)); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		err = filepath.WalkDir(path, func(filePath string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if !entry.Type().IsRegular() {
+				return nil
+			}
+			relativePath, err := filepath.Rel(path, filePath)
+			if err != nil {
+				return err
+			}
+			return addFile(filePath, filepath.ToSlash(relativePath))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to read directory %s: %w", path, err)
+		}
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no files found")
+	}
+	return files, nil
+}
+
+func containsAnyHash(hashes map[string]bool, candidates []string) bool {
+	for _, candidate := range candidates {
+		if hashes[candidate] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,147 @@
+package gotestguide
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_AddArtifacts(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	dir := t.TempDir()
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "plots"), os.ModePerm))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "log.txt"), []byte("log"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "plots", "speed.png"), []byte("speed"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "plots", "log-copy.txt"), []byte("log"), 0644))
+	speedHash := md5.Sum([]byte("speed"))
+	tces := []*TestCaseExecution{
+		{ID: 10, Artifacts: []*FileReference{{Filename: "speed.png", FileHash: hex.EncodeToString(speedHash[:])}}},
+		{ID: 11},
+		{ID: 12},
+	}
+	var mutex sync.Mutex
+	uploaded := []string{}
+	mux.HandleFunc("/api/report/testCaseExecution/", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPut)
+		if r.URL.Path == "/api/report/testCaseExecution/12/artifacts" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		assert.NoError(r.ParseMultipartForm(1 << 20))
+		assert.Equal("Bench logs", r.FormValue("comment"))
+		assert.Empty(r.FormValue("category"), "Should not send an empty category")
+		_, header, err := r.FormFile("file")
+		assert.NoError(err)
+		mutex.Lock()
+		uploaded = append(uploaded, r.URL.Path+" "+header.Filename)
+		mutex.Unlock()
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Execute
+	results, err := client.ReportManagement.AddArtifacts(tces, []string{dir}, &AddArtifactsOptions{Comment: "Bench logs", Workers: 2})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(results, 3)
+	assert.NoError(results[0].Error)
+	assert.Equal([]string{filepath.Join(dir, "log.txt")}, results[0].Added)
+	assert.Equal([]string{filepath.Join(dir, "plots", "speed.png")}, results[0].Skipped, "Should skip files which are already attached")
+	assert.NoError(results[1].Error)
+	assert.Len(results[1].Added, 2, "Should add identical files only once")
+	assert.Error(results[2].Error)
+	slices.Sort(uploaded)
+	assert.Equal([]string{
+		"/api/report/testCaseExecution/10/artifacts log.txt",
+		"/api/report/testCaseExecution/11/artifacts log.txt",
+		"/api/report/testCaseExecution/11/artifacts speed.png",
+	}, uploaded)
+}
+
+func TestReportManagement_AddArtifacts_KeepDuplicates(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "a.txt"), []byte("same"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "b.txt"), []byte("same"), 0644))
+	mux.HandleFunc("/api/report/testCaseExecution/10/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Execute
+	results, err := client.ReportManagement.AddArtifacts([]*TestCaseExecution{{ID: 10}}, []string{dir}, &AddArtifactsOptions{KeepDuplicates: true})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(results[0].Added, 2)
+}
+
+func TestReportManagement_AddArtifacts_NoFiles(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+
+	// Execute
+	_, err := client.ReportManagement.AddArtifacts([]*TestCaseExecution{{ID: 10}}, []string{t.TempDir()}, nil)
+
+	// Verify
+	assert.Error(err, "Should fail without files")
+}
+
+func TestReportManagement_AddArtifacts_NestedDirectories(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	dir := t.TempDir()
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "a"), 0755))
+	assert.NoError(os.MkdirAll(filepath.Join(dir, "b"), 0755))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "a", "log.txt"), []byte("first"), 0644))
+	assert.NoError(os.WriteFile(filepath.Join(dir, "b", "log.txt"), []byte("second"), 0644))
+	uploaded := []string{}
+	mux.HandleFunc("/api/report/testCaseExecution/10/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		// The parsed multipart form strips the directories of the file name
+		reader, err := r.MultipartReader()
+		assert.NoError(err)
+		part, err := reader.NextPart()
+		assert.NoError(err)
+		_, params, err := mime.ParseMediaType(part.Header.Get("Content-Disposition"))
+		assert.NoError(err)
+		uploaded = append(uploaded, params["filename"])
+		w.WriteHeader(http.StatusOK)
+	})
+
+	// Execute
+	results, err := client.ReportManagement.AddArtifacts([]*TestCaseExecution{{ID: 10}}, []string{dir}, &AddArtifactsOptions{Workers: 1})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.NoError(results[0].Error)
+	assert.Equal([]string{"a/log.txt", "b/log.txt"}, uploaded, "Should name the files by their relative path")
+}
+
+func TestReportManagement_AddArtifacts_SameName(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+	dir := t.TempDir()
+	filePath := filepath.Join(t.TempDir(), "log.txt")
+	assert.NoError(os.WriteFile(filepath.Join(dir, "log.txt"), []byte("first"), 0644))
+	assert.NoError(os.WriteFile(filePath, []byte("second"), 0644))
+
+	// Execute
+	_, err := client.ReportManagement.AddArtifacts([]*TestCaseExecution{{ID: 10}}, []string{dir, filePath}, nil)
+
+	// Verify
+	assert.ErrorContains(err, "would both be added as log.txt", "Should reject different files with the same name")
+}
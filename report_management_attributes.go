@@ -0,0 +1,109 @@
+package gotestguide
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+////////////////////////////////////////////////////////////
+// AttributeUpdate
+////////////////////////////////////////////////////////////
+
+// The changes to the attributes of test case executions.
+type AttributeUpdate struct {
+	// Attributes to add, existing attributes with the same key are replaced.
+	Set []*Attribute
+	// Keys of the attributes to remove.
+	Remove []string
+}
+
+func (u *AttributeUpdate) String() string {
+	return fmt.Sprintf("AttributeUpdate(Set: %v, Remove: %v)", u.Set, u.Remove)
+}
+
+////////////////////////////////////////////////////////////
+// BulkAttributeResult
+////////////////////////////////////////////////////////////
+
+// The result of updating the attributes of a single test case execution.
+type BulkAttributeResult struct {
+	TceID int64
+	Error error
+}
+
+func (r *BulkAttributeResult) String() string {
+	return fmt.Sprintf("BulkAttributeResult(TceID: %d, Error: %v)", r.TceID, r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// Attributes
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) SetAttributes(tceId int64, attributes []*Attribute) ([]*Attribute, *http.Response, error) {
+	// Prepare the body
+	bodyBytes, err := json.Marshal(attributes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	// Prepare the request
+	req, err := s.client.NewRequest(http.MethodPut, fmt.Sprintf("api/report/testCaseExecution/%d/attributes", tceId), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	var responseObject = []*Attribute{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) DeleteAttribute(tceId int64, key string) (*http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodDelete, fmt.Sprintf("api/report/testCaseExecution/%d/attributes/%s", tceId, url.PathEscape(key)), nil)
+	if err != nil {
+		return nil, err
+	}
+	return s.client.Do(req, nil)
+}
+
+func (s *ReportManagementService) UpdateAttributes(tceIds []int64, update *AttributeUpdate, workers int) []*BulkAttributeResult {
+	results := make([]*BulkAttributeResult, len(tceIds))
+	forEachParallel(len(tceIds), workers, func(i int) {
+		results[i] = &BulkAttributeResult{TceID: tceIds[i], Error: s.updateAttributes(tceIds[i], update)}
+	})
+	return results
+}
+
+func (s *ReportManagementService) UpdateAttributesByFilter(projectId int, filter *FilterParameters, update *AttributeUpdate, workers int) ([]*BulkAttributeResult, error) {
+	tces, err := s.GetAllTestCaseExecutionsByFilter(projectId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test case executions: %w", err)
+	}
+	tceIds := make([]int64, len(tces))
+	for i, tce := range tces {
+		tceIds[i] = tce.ID
+	}
+	return s.UpdateAttributes(tceIds, update, workers), nil
+}
+
+// Applies the update to a single test case execution, removals are applied after the new attributes are set.
+func (s *ReportManagementService) updateAttributes(tceId int64, update *AttributeUpdate) error {
+	if len(update.Set) > 0 {
+		if _, _, err := s.SetAttributes(tceId, update.Set); err != nil {
+			return fmt.Errorf("failed to set attributes: %w", err)
+		}
+	}
+	for _, key := range update.Remove {
+		if _, err := s.DeleteAttribute(tceId, key); err != nil {
+			return fmt.Errorf("failed to remove attribute %s: %w", key, err)
+		}
+	}
+	return nil
+}
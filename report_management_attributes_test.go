@@ -0,0 +1,102 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_SetAttributes(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecution/10/attributes", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPut)
+		var attributes []*Attribute
+		assert.NoError(json.NewDecoder(r.Body).Decode(&attributes))
+		assert.Equal([]*Attribute{{Key: "Ticket", Values: []string{"ABC-1", "ABC-2"}}}, attributes)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"key": "Ticket", "values": ["ABC-1", "ABC-2"]}, {"key": "Bench", "value": "HIL1"}]`))
+	})
+
+	// Execute
+	attributes, _, err := client.ReportManagement.SetAttributes(10, []*Attribute{{Key: "Ticket", Values: []string{"ABC-1", "ABC-2"}}})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(attributes, 2)
+	assert.Equal("HIL1", attributes[1].Value)
+}
+
+func TestReportManagement_DeleteAttribute(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecution/10/attributes/", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodDelete)
+		assert.Equal("/api/report/testCaseExecution/10/attributes/Release%20Candidate", r.URL.EscapedPath())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Execute
+	_, err := client.ReportManagement.DeleteAttribute(10, "Release Candidate")
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+}
+
+func TestReportManagement_UpdateAttributesByFilter(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecutions/filter", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "offset", "0")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 10}, {"id": 11}, {"id": 12}]`))
+	})
+	var mutex sync.Mutex
+	requests := []string{}
+	mux.HandleFunc("/api/report/testCaseExecution/", func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mutex.Unlock()
+		if r.URL.Path == "/api/report/testCaseExecution/11/attributes" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodPut {
+			w.Write([]byte(`[]`))
+		}
+	})
+	update := &AttributeUpdate{Set: []*Attribute{{Key: "RC", Value: "rc1"}}, Remove: []string{"Draft"}}
+
+	// Execute
+	results, err := client.ReportManagement.UpdateAttributesByFilter(1, &FilterParameters{}, update, 2)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(results, 3, "Should contain a result per test case execution")
+	for i, result := range results {
+		assert.Equal(int64(10+i), result.TceID, "Results should be in the order of the test case executions")
+		if result.TceID == 11 {
+			assert.Error(result.Error, fmt.Sprintf("Update of %d should fail", result.TceID))
+		} else {
+			assert.NoError(result.Error, fmt.Sprintf("Update of %d should succeed", result.TceID))
+		}
+	}
+	slices.Sort(requests)
+	assert.Equal([]string{
+		"DELETE /api/report/testCaseExecution/10/attributes/Draft",
+		"DELETE /api/report/testCaseExecution/12/attributes/Draft",
+		"PUT /api/report/testCaseExecution/10/attributes",
+		"PUT /api/report/testCaseExecution/11/attributes",
+		"PUT /api/report/testCaseExecution/12/attributes",
+	}, requests, "Should not remove attributes if setting them failed")
+}
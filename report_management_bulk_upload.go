@@ -0,0 +1,188 @@
+package gotestguide
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// BulkUploadItem
+////////////////////////////////////////////////////////////
+
+// A single report to upload with BulkUpload.
+// Either ConverterID and ReportPath or Report must be set.
+type BulkUploadItem struct {
+	// ID of the converter used for the report file.
+	ConverterID string
+	// Path to the report file. Together with Report, the path of the file the report was read from,
+	// the files referenced by the report are then uploaded from its directory.
+	ReportPath string
+	// Typed report which is uploaded with the json2atx converter.
+	Report *UploadReport
+}
+
+func (i *BulkUploadItem) String() string {
+	if i.Report != nil {
+		return fmt.Sprintf("BulkUploadItem(Report: %s)", i.Report.Name)
+	}
+	return fmt.Sprintf("BulkUploadItem(ConverterID: %s, ReportPath: %s)", i.ConverterID, i.ReportPath)
+}
+
+////////////////////////////////////////////////////////////
+// BulkUploadOptions
+////////////////////////////////////////////////////////////
+
+type BulkUploadOptions struct {
+	// Number of reports which are uploaded at the same time. Defaults to 4.
+	Workers int
+	// Interval to check the upload status. Defaults to 1 second.
+	PollInterval time.Duration
+	// Maximum time to wait for each upload task. Zero waits forever.
+	Timeout time.Duration
+	// Derive the OptionalReportIdentifier of typed reports without one from their content and PipelineID,
+	// so that uploading them again (e.g. on a retry) is detected as double upload.
+	// All items must then have a typed report, report files without one cannot get an identifier.
+	DeriveIdentifiers bool
+	// ID of the pipeline which created the reports, required to derive the identifiers.
+	// Without it, the same report from another run would be detected as double upload.
+	PipelineID string
+}
+
+////////////////////////////////////////////////////////////
+// BulkUploadResult
+////////////////////////////////////////////////////////////
+
+// The result of a single item of a bulk upload.
+type BulkUploadResult struct {
+	Item   *BulkUploadItem
+	TaskID string
+	// The identifier of the uploaded typed report, if any.
+	ReportIdentifier string
+	// The ID of the created report or, for a double upload, of the existing report.
+	ReportID       int
+	IsDoubleUpload bool
+	Status         *UploadStatus
+	Error          error
+}
+
+func (r *BulkUploadResult) String() string {
+	return fmt.Sprintf("BulkUploadResult(Item: %s, TaskID: %s, ReportID: %d, IsDoubleUpload: %t, Error: %v)",
+		r.Item, r.TaskID, r.ReportID, r.IsDoubleUpload, r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// BulkUploadSummary
+////////////////////////////////////////////////////////////
+
+// The results of a bulk upload in the same order as the uploaded items.
+type BulkUploadSummary struct {
+	Results []*BulkUploadResult
+}
+
+func (s *BulkUploadSummary) String() string {
+	return fmt.Sprintf("BulkUploadSummary(Total: %d, Succeeded: %d, DoubleUploads: %d, Failed: %d)",
+		len(s.Results), len(s.Succeeded()), len(s.DoubleUploads()), len(s.Failed()))
+}
+
+// Get all results which created a new report.
+func (s *BulkUploadSummary) Succeeded() []*BulkUploadResult {
+	return s.filter(func(r *BulkUploadResult) bool { return r.Error == nil && !r.IsDoubleUpload })
+}
+
+// Get all results which were already uploaded before.
+func (s *BulkUploadSummary) DoubleUploads() []*BulkUploadResult {
+	return s.filter(func(r *BulkUploadResult) bool { return r.Error == nil && r.IsDoubleUpload })
+}
+
+// Get all results which failed.
+func (s *BulkUploadSummary) Failed() []*BulkUploadResult {
+	return s.filter(func(r *BulkUploadResult) bool { return r.Error != nil })
+}
+
+func (s *BulkUploadSummary) filter(predicate func(r *BulkUploadResult) bool) []*BulkUploadResult {
+	ret := []*BulkUploadResult{}
+	for _, result := range s.Results {
+		if predicate(result) {
+			ret = append(ret, result)
+		}
+	}
+	return ret
+}
+
+////////////////////////////////////////////////////////////
+// BulkUpload
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) BulkUpload(projectId int, items []*BulkUploadItem, options *BulkUploadOptions) (*BulkUploadSummary, error) {
+	// Validate the input
+	for i, item := range items {
+		if item == nil {
+			return nil, fmt.Errorf("item %d is nil", i)
+		}
+		if item.Report == nil && (item.ConverterID == "" || item.ReportPath == "") {
+			return nil, fmt.Errorf("item %d needs either a report or a converter and a report path", i)
+		}
+		if options != nil && options.DeriveIdentifiers && item.Report == nil {
+			return nil, fmt.Errorf("item %d needs a typed report to derive its identifier", i)
+		}
+	}
+	if options != nil && options.DeriveIdentifiers && options.PipelineID == "" {
+		return nil, fmt.Errorf("a pipeline ID is required to derive the identifiers")
+	}
+	// Apply the defaults
+	resolvedOptions := BulkUploadOptions{}
+	if options != nil {
+		resolvedOptions = *options
+	}
+	if resolvedOptions.Workers <= 0 {
+		resolvedOptions.Workers = 4
+	}
+	if resolvedOptions.PollInterval <= 0 {
+		resolvedOptions.PollInterval = 1 * time.Second
+	}
+
+	// Upload and wait for all items
+	summary := &BulkUploadSummary{Results: make([]*BulkUploadResult, len(items))}
+	forEachParallel(len(items), resolvedOptions.Workers, func(i int) {
+		summary.Results[i] = s.uploadAndWait(projectId, items[i], &resolvedOptions)
+	})
+	return summary, nil
+}
+
+// Uploads a single item and waits until it is processed.
+func (s *ReportManagementService) uploadAndWait(projectId int, item *BulkUploadItem, options *BulkUploadOptions) *BulkUploadResult {
+	result := &BulkUploadResult{Item: item}
+	var task *TaskRef
+	var err error
+	if item.Report != nil {
+		report := item.Report
+		if options.DeriveIdentifiers {
+			if report, err = report.WithDerivedIdentifier(options.PipelineID); err != nil {
+				result.Error = err
+				return result
+			}
+		}
+		result.ReportIdentifier = report.OptionalReportIdentifier
+		if item.ReportPath != "" {
+			task, _, err = s.UploadReportTypedWithFiles(projectId, report, filepath.Dir(item.ReportPath))
+		} else {
+			task, _, err = s.UploadReportTyped(projectId, report)
+		}
+	} else {
+		task, _, err = s.UploadReport(projectId, item.ConverterID, item.ReportPath)
+	}
+	if err != nil {
+		result.Error = fmt.Errorf("failed to upload report: %w", err)
+		return result
+	}
+	result.TaskID = task.TaskID
+	status, _, err := s.WaitForUpload(task.TaskID, options.PollInterval, options.Timeout)
+	result.Status = status
+	if status != nil {
+		result.ReportID = status.UploadResult.ReportID
+		result.IsDoubleUpload = status.UploadResult.IsDoubleUpload
+	}
+	result.Error = err
+	return result
+}
@@ -0,0 +1,130 @@
+package gotestguide
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_BulkUpload(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var taskCounter atomic.Int32
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "projectId", "1")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"taskId": "task%d"}`, taskCounter.Add(1))
+	})
+	var statusCalls atomic.Int32
+	mux.HandleFunc("/api/report/reports/uploadstatus/", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		// Report a running task for the first call
+		if statusCalls.Add(1) == 1 {
+			w.Write([]byte(`{"status": "running"}`))
+			return
+		}
+		taskNumber := strings.TrimPrefix(filepath.Base(r.URL.Path), "task")
+		if taskNumber == "2" {
+			fmt.Fprintf(w, `{"status": "finished", "uploadResult": {"reportId": 100, "isDoubleUpload": true}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "finished", "uploadResult": {"reportId": %s}}`, taskNumber)
+	})
+	reportPath := filepath.Join(t.TempDir(), "report.xml")
+	assert.NoError(os.WriteFile(reportPath, []byte("<testsuites/>"), 0644))
+
+	items := []*BulkUploadItem{
+		{ConverterID: "junit", ReportPath: reportPath},
+		{ConverterID: "junit", ReportPath: filepath.Join(t.TempDir(), "missing.xml")},
+		{Report: &UploadReport{Name: "Typed Report"}},
+	}
+
+	// Execute
+	summary, err := client.ReportManagement.BulkUpload(1, items, &BulkUploadOptions{Workers: 2, PollInterval: time.Millisecond})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(summary.Results, 3, "Should contain a result per item")
+	assert.Len(summary.Failed(), 1, "Should contain the missing file as failure")
+	assert.Same(items[1], summary.Failed()[0].Item, "Failed result should reference the item")
+	assert.Len(summary.Succeeded(), 1, "Should contain the new report")
+	assert.Len(summary.DoubleUploads(), 1, "Should contain the double upload")
+	assert.Equal(100, summary.DoubleUploads()[0].ReportID, "Double upload should return the existing report ID")
+}
+
+func TestReportManagement_BulkUpload_DeriveIdentifiers(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var mutex sync.Mutex
+	uploadedIdentifiers := map[string]bool{}
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		identifier := readUploadedTypedReport(assert, r).OptionalReportIdentifier
+		mutex.Lock()
+		defer mutex.Unlock()
+		// The server detects reports with a known identifier as double upload
+		taskId := "new"
+		if uploadedIdentifiers[identifier] {
+			taskId = "double"
+		}
+		uploadedIdentifiers[identifier] = true
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"taskId": "%s"}`, taskId)
+	})
+	mux.HandleFunc("/api/report/reports/uploadstatus/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if filepath.Base(r.URL.Path) == "double" {
+			w.Write([]byte(`{"status": "finished", "uploadResult": {"reportId": 100, "isDoubleUpload": true}}`))
+			return
+		}
+		w.Write([]byte(`{"status": "finished", "uploadResult": {"reportId": 100}}`))
+	})
+	items := []*BulkUploadItem{
+		{Report: &UploadReport{Name: "Typed Report", Timestamp: 1700000000000}},
+		{Report: &UploadReport{Name: "Typed Report", Timestamp: 1700000000000, OptionalReportIdentifier: "existing"}},
+	}
+	options := &BulkUploadOptions{Workers: 1, PollInterval: time.Millisecond, DeriveIdentifiers: true, PipelineID: "1234"}
+	expectedIdentifier, err := items[0].Report.DeriveIdentifier("1234")
+	assert.NoError(err)
+
+	// Execute
+	first, firstErr := client.ReportManagement.BulkUpload(1, items, options)
+	retry, retryErr := client.ReportManagement.BulkUpload(1, items[:1], options)
+
+	// Verify
+	assert.NoError(firstErr, "Should not return an error")
+	assert.NoError(retryErr, "Should not return an error")
+	assert.Len(first.Succeeded(), 2)
+	assert.Equal(expectedIdentifier, first.Results[0].ReportIdentifier)
+	assert.Equal("existing", first.Results[1].ReportIdentifier, "Should keep an existing identifier")
+	assert.Empty(items[0].Report.OptionalReportIdentifier, "Should not modify the item")
+	assert.Len(retry.DoubleUploads(), 1, "Should detect the retry as double upload")
+	assert.Equal(100, retry.Results[0].ReportID, "Should return the existing report ID")
+}
+
+func TestReportManagement_BulkUpload_DeriveIdentifiersInvalid(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+	typedItems := []*BulkUploadItem{{Report: &UploadReport{Name: "Typed Report"}}}
+	fileItems := []*BulkUploadItem{{ConverterID: "junit", ReportPath: "report.xml"}}
+
+	// Execute
+	_, noPipelineErr := client.ReportManagement.BulkUpload(1, typedItems, &BulkUploadOptions{DeriveIdentifiers: true})
+	_, fileErr := client.ReportManagement.BulkUpload(1, fileItems, &BulkUploadOptions{DeriveIdentifiers: true, PipelineID: "1234"})
+
+	// Verify
+	assert.ErrorContains(noPipelineErr, "pipeline ID is required", "Should fail without a pipeline ID")
+	assert.ErrorContains(fileErr, "needs a typed report", "Should fail for report files")
+}
@@ -0,0 +1,294 @@
+package gotestguide
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Error if the content of a downloaded file does not match its hash.
+var ErrHashMismatch = errors.New("hash mismatch")
+
+////////////////////////////////////////////////////////////
+// Downloads
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) DownloadFile(file *FileReference) (io.ReadCloser, *http.Response, error) {
+	req, err := s.newDownloadRequest(file.DownloadURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.DoRaw(req)
+	if err != nil {
+		return nil, resp, err
+	}
+	hasher := newFileHasher(file.FileHash)
+	if hasher == nil {
+		return resp.Body, resp, nil
+	}
+	return &verifyingReadCloser{ReadCloser: resp.Body, hasher: hasher, expectedHash: file.FileHash}, resp, nil
+}
+
+func (s *ReportManagementService) DownloadFileTo(file *FileReference, path string) error {
+	// Skip files which were already downloaded completely, files without size and hash cannot be checked and are downloaded again
+	canVerify := file.FileSize > 0 || newFileHasher(file.FileHash) != nil
+	if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() && canVerify {
+		if file.FileSize == 0 || info.Size() == file.FileSize {
+			if err := verifyFileHash(path, file.FileHash); err == nil {
+				return nil
+			}
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	// Continue a previously interrupted download, this needs the size to know when the file is complete
+	partPath := path + ".part"
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil && file.FileSize > 0 {
+		offset = info.Size()
+	}
+	complete := false
+	if file.FileSize > 0 && offset >= file.FileSize {
+		// Start from scratch if the partial file is invalid
+		complete = offset == file.FileSize && verifyFileHash(partPath, file.FileHash) == nil
+		offset = 0
+	}
+	if !complete {
+		if err := s.downloadToPartFile(file.DownloadURL, partPath, offset); err != nil {
+			return err
+		}
+	}
+
+	if err := verifyFileHash(partPath, file.FileHash); err != nil {
+		// Do not resume from a corrupt file
+		os.Remove(partPath)
+		return fmt.Errorf("failed to verify %s: %w", path, err)
+	}
+	if err := os.Rename(partPath, path); err != nil {
+		return fmt.Errorf("failed to move %s: %w", partPath, err)
+	}
+	return nil
+}
+
+// Downloads the file into the part file, starting at the given offset if it is greater than zero.
+func (s *ReportManagementService) downloadToPartFile(downloadUrl string, partPath string, offset int64) error {
+	req, err := s.newDownloadRequest(downloadUrl)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := s.client.DoRaw(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if offset > 0 && resp.StatusCode == http.StatusPartialContent {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	partFile, err := os.OpenFile(partPath, flags, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", partPath, err)
+	}
+	if _, err := io.Copy(partFile, resp.Body); err != nil {
+		partFile.Close()
+		return fmt.Errorf("failed to download %s: %w", downloadUrl, err)
+	}
+	return partFile.Close()
+}
+
+// Creates a request for a download URL which is either absolute or relative to the base URL.
+// The authentication is only sent to the test.guide server itself.
+func (s *ReportManagementService) newDownloadRequest(downloadUrl string) (*http.Request, error) {
+	parsedUrl, err := url.Parse(downloadUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid download URL: %w", err)
+	}
+	baseUrl := *s.client.baseUrl
+	if !strings.HasSuffix(baseUrl.Path, "/") {
+		baseUrl.Path += "/"
+	}
+	resolvedUrl := baseUrl.ResolveReference(parsedUrl)
+	req, err := http.NewRequest(http.MethodGet, resolvedUrl.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if resolvedUrl.Host == baseUrl.Host {
+		req.Header.Set("TestGuide-AuthKey", s.client.authKey)
+	}
+	return req, nil
+}
+
+////////////////////////////////////////////////////////////
+// Hash verification
+////////////////////////////////////////////////////////////
+
+// The hashes which the server may use for files (MD5, SHA-1 and SHA-256), they differ in the length of the hex encoded hash.
+var fileHashFuncs = []func() hash.Hash{md5.New, sha1.New, sha256.New}
+
+// Creates the hash matching the length of the hex encoded file hash.
+// Returns nil for unknown or empty hashes.
+func newFileHasher(fileHash string) hash.Hash {
+	for _, newHash := range fileHashFuncs {
+		if hasher := newHash(); len(fileHash) == 2*hasher.Size() {
+			return hasher
+		}
+	}
+	return nil
+}
+
+// Calculates the hex encoded hashes of the file with the given hash functions, reading the file only once.
+func calculateFileHashes(path string, newHashes ...func() hash.Hash) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	hashers := make([]hash.Hash, len(newHashes))
+	writers := make([]io.Writer, len(newHashes))
+	for i, newHash := range newHashes {
+		hashers[i] = newHash()
+		writers[i] = hashers[i]
+	}
+	if _, err := io.Copy(io.MultiWriter(writers...), file); err != nil {
+		return nil, err
+	}
+	hashes := make([]string, len(hashers))
+	for i, hasher := range hashers {
+		hashes[i] = hex.EncodeToString(hasher.Sum(nil))
+	}
+	return hashes, nil
+}
+
+// Verifies that the content of the file matches the file hash, unknown hashes are not verified.
+func verifyFileHash(path string, fileHash string) error {
+	hasher := newFileHasher(fileHash)
+	if hasher == nil {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if _, err := io.Copy(hasher, file); err != nil {
+		return err
+	}
+	return checkHash(hasher, fileHash)
+}
+
+func checkHash(hasher hash.Hash, expectedHash string) error {
+	actualHash := hex.EncodeToString(hasher.Sum(nil))
+	if !strings.EqualFold(actualHash, expectedHash) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrHashMismatch, expectedHash, actualHash)
+	}
+	return nil
+}
+
+// A body which verifies the hash of the content when the end is reached.
+type verifyingReadCloser struct {
+	io.ReadCloser
+	hasher       hash.Hash
+	expectedHash string
+}
+
+func (r *verifyingReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	r.hasher.Write(p[:n])
+	if err == io.EOF {
+		if hashErr := checkHash(r.hasher, r.expectedHash); hashErr != nil {
+			return n, hashErr
+		}
+	}
+	return n, err
+}
+
+////////////////////////////////////////////////////////////
+// ArtifactDownloadResult
+////////////////////////////////////////////////////////////
+
+// The result of downloading a single file with DownloadTestCaseExecutionFiles.
+type ArtifactDownloadResult struct {
+	TceID int64
+	File  *FileReference
+	Path  string
+	Error error
+}
+
+func (r *ArtifactDownloadResult) String() string {
+	return fmt.Sprintf("ArtifactDownloadResult(TceID: %d, Path: %s, Error: %v)", r.TceID, r.Path, r.Error)
+}
+
+// Downloads the artifacts and the attachments of the last review of the test case executions into a directory tree.
+// The files are stored as <dir>/<report ID>/<test case execution ID>/<relative path>, review attachments in a "review" subdirectory.
+func (s *ReportManagementService) DownloadTestCaseExecutionFiles(tces []*TestCaseExecution, dir string, workers int) []*ArtifactDownloadResult {
+	results := []*ArtifactDownloadResult{}
+	usedPaths := map[string]bool{}
+	for _, tce := range tces {
+		tceDir := filepath.Join(dir, fmt.Sprint(tce.ReportID), fmt.Sprint(tce.ID))
+		for _, artifact := range tce.Artifacts {
+			path := uniqueFilePath(filepath.Join(tceDir, localFilePath(artifact)), artifact.ID, usedPaths)
+			results = append(results, &ArtifactDownloadResult{TceID: tce.ID, File: artifact, Path: path})
+		}
+		if tce.LastReview != nil {
+			for _, attachment := range tce.LastReview.Attachments {
+				path := uniqueFilePath(filepath.Join(tceDir, "review", localFilePath(attachment)), attachment.ID, usedPaths)
+				results = append(results, &ArtifactDownloadResult{TceID: tce.ID, File: attachment, Path: path})
+			}
+		}
+	}
+	forEachParallel(len(results), workers, func(i int) {
+		results[i].Error = s.DownloadFileTo(results[i].File, results[i].Path)
+	})
+	return results
+}
+
+// Gets the relative local path of a file, falling back to the file name if the relative path leaves the directory.
+func localFilePath(file *FileReference) string {
+	path := filepath.FromSlash(file.RelPath)
+	if path == "" {
+		path = file.Filename
+	} else if filepath.Base(path) != file.Filename && file.Filename != "" {
+		path = filepath.Join(path, file.Filename)
+	}
+	if !filepath.IsLocal(path) {
+		path = filepath.Base(filepath.FromSlash(file.Filename))
+	}
+	if !filepath.IsLocal(path) {
+		path = fmt.Sprintf("file-%d", file.ID)
+	}
+	return path
+}
+
+// Gets a path which is not used by another file of the same download, so that parallel downloads never share a file.
+// Colliding paths get the file ID (and if needed a counter) appended to the name. Paths are compared case-insensitively
+// to also avoid collisions on case-insensitive file systems.
+func uniqueFilePath(path string, fileId int64, usedPaths map[string]bool) string {
+	ext := filepath.Ext(path)
+	base := strings.TrimSuffix(path, ext)
+	candidate := path
+	for i := 1; usedPaths[strings.ToLower(candidate)]; i++ {
+		candidate = fmt.Sprintf("%s-%d%s", base, fileId, ext)
+		if i > 1 {
+			candidate = fmt.Sprintf("%s-%d-%d%s", base, fileId, i, ext)
+		}
+	}
+	usedPaths[strings.ToLower(candidate)] = true
+	return candidate
+}
@@ -0,0 +1,201 @@
+package gotestguide
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_DownloadFile(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	content := []byte("recording data")
+	sum := sha256.Sum256(content)
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		assert.Equal("token", r.Header.Get("TestGuide-AuthKey"), "Authentication should be sent")
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	})
+
+	// Execute
+	reader, _, err := client.ReportManagement.DownloadFile(&FileReference{DownloadURL: "/api/report/files/1", FileHash: hex.EncodeToString(sum[:])})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	data, err := io.ReadAll(reader)
+	assert.NoError(err, "Reading should not return an error")
+	assert.NoError(reader.Close())
+	assert.Equal(content, data)
+
+	// Execute with a wrong hash
+	reader, _, err = client.ReportManagement.DownloadFile(&FileReference{DownloadURL: "api/report/files/1", FileHash: "00112233445566778899aabbccddeeff"})
+
+	// Verify
+	assert.NoError(err, "Should not return an error before reading")
+	_, err = io.ReadAll(reader)
+	assert.ErrorIs(err, ErrHashMismatch, "Reading should fail with a hash mismatch")
+	reader.Close()
+}
+
+func TestReportManagement_DownloadFileTo(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	content := []byte("0123456789abcdefghij")
+	sum := md5.Sum(content)
+	ranges := []string{}
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		var start int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-", &start); err == nil {
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, len(content)-1, len(content)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(content[start:])
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write(content)
+	})
+	file := &FileReference{DownloadURL: "api/report/files/1", FileSize: int64(len(content)), FileHash: hex.EncodeToString(sum[:])}
+	path := filepath.Join(t.TempDir(), "sub", "file.bin")
+	assert.NoError(os.MkdirAll(filepath.Dir(path), os.ModePerm))
+	assert.NoError(os.WriteFile(path+".part", content[:8], 0644), "Prepare an interrupted download")
+
+	// Execute
+	err := client.ReportManagement.DownloadFileTo(file, path)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	data, _ := os.ReadFile(path)
+	assert.Equal(content, data, "Content should be complete")
+	assert.NoFileExists(path+".part", "Partial file should be removed")
+	assert.Equal([]string{"bytes=8-"}, ranges, "Download should be resumed")
+
+	// Execute again
+	err = client.ReportManagement.DownloadFileTo(file, path)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(ranges, 1, "Complete files should not be downloaded again")
+}
+
+func TestReportManagement_DownloadFileTo_Unverifiable(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	downloads := 0
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		downloads++
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("complete"))
+	})
+	file := &FileReference{DownloadURL: "api/report/files/1"}
+	path := filepath.Join(t.TempDir(), "file.bin")
+	assert.NoError(os.WriteFile(path, []byte("trunc"), 0644), "Prepare a file of unknown state")
+
+	// Execute
+	err := client.ReportManagement.DownloadFileTo(file, path)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(1, downloads, "Files without size and hash should be downloaded again")
+	data, _ := os.ReadFile(path)
+	assert.Equal("complete", string(data))
+}
+
+func TestReportManagement_DownloadFileTo_HashMismatch(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/files/1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("corrupt"))
+	})
+	path := filepath.Join(t.TempDir(), "file.bin")
+
+	// Execute
+	err := client.ReportManagement.DownloadFileTo(&FileReference{DownloadURL: "api/report/files/1", FileHash: "00112233445566778899aabbccddeeff"}, path)
+
+	// Verify
+	assert.ErrorIs(err, ErrHashMismatch, "Should return a hash mismatch")
+	assert.NoFileExists(path)
+	assert.NoFileExists(path+".part", "Corrupt partial file should be removed")
+}
+
+func TestReportManagement_DownloadFile_ForeignHost(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	_, client := setup(t)
+	otherMux, otherClient := setup(t)
+	otherMux.HandleFunc("/files/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(r.Header.Get("TestGuide-AuthKey"), "Authentication should not be sent to other hosts")
+		w.WriteHeader(http.StatusOK)
+	})
+	req, _ := otherClient.NewRequest(http.MethodGet, "files/1", nil)
+
+	// Execute
+	reader, _, err := client.ReportManagement.DownloadFile(&FileReference{DownloadURL: req.URL.String()})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	reader.Close()
+}
+
+func TestReportManagement_DownloadTestCaseExecutionFiles(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/files/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/report/files/3" {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(r.URL.Path))
+	})
+	tces := []*TestCaseExecution{
+		{ID: 10, ReportID: 1, Artifacts: []*FileReference{
+			{ID: 1, Filename: "trace.asc", RelPath: "recordings", DownloadURL: "api/report/files/1"},
+			{ID: 2, Filename: "escape.txt", RelPath: "../../escape.txt", DownloadURL: "api/report/files/2"},
+			{ID: 5, Filename: "trace.asc", RelPath: "recordings", DownloadURL: "api/report/files/5"},
+		}, LastReview: &Review{Attachments: []*FileReference{
+			{ID: 4, Filename: "screenshot.png", DownloadURL: "api/report/files/4"},
+		}}},
+		{ID: 11, ReportID: 1, Artifacts: []*FileReference{
+			{ID: 3, Filename: "broken.bin", DownloadURL: "api/report/files/3"},
+		}},
+	}
+	dir := t.TempDir()
+
+	// Execute
+	results := client.ReportManagement.DownloadTestCaseExecutionFiles(tces, dir, 2)
+
+	// Verify
+	assert.Len(results, 5, "Should contain a result per file")
+	paths := map[string]bool{}
+	for _, result := range results {
+		rel, _ := filepath.Rel(dir, result.Path)
+		paths[filepath.ToSlash(rel)] = result.Error == nil
+	}
+	assert.Equal(map[string]bool{
+		"1/10/recordings/trace.asc":   true,
+		"1/10/recordings/trace-5.asc": true,
+		"1/10/escape.txt":             true,
+		"1/10/review/screenshot.png":  true,
+		"1/11/broken.bin":             false,
+	}, paths, "Files should be stored in the directory tree")
+	data, _ := os.ReadFile(filepath.Join(dir, "1", "10", "recordings", "trace.asc"))
+	assert.Equal("/api/report/files/1", string(data))
+	data, _ = os.ReadFile(filepath.Join(dir, "1", "10", "recordings", "trace-5.asc"))
+	assert.Equal("/api/report/files/5", string(data), "Files with the same path should not overwrite each other")
+}
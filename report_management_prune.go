@@ -0,0 +1,273 @@
+package gotestguide
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// PruneOptions
+////////////////////////////////////////////////////////////
+
+// Options to select the reports which are deleted by PruneReports.
+// A report is only deleted if it matches all given criteria and no keep rule applies.
+// At least one criterion (From, To, OlderThan, Statuses, TestPlanPattern or Filter) is required.
+type PruneOptions struct {
+	// Start and end of the upload dates of the report history to consider. Default to the beginning of time and now.
+	From time.Time
+	To   time.Time
+	// Only delete reports which were executed longer ago than this.
+	OlderThan time.Duration
+	// Only delete reports with one of these statuses. Empty allows all statuses.
+	Statuses []ReportStatus
+	// Only delete reports whose test plan name matches this pattern (path.Match syntax). Empty allows all test plans.
+	TestPlanPattern string
+	// Only delete reports with at least one test case execution matching the filter parameters.
+	Filter *FilterParameters
+	// Keep the latest N reports (by execution date) of each test plan.
+	KeepLast int
+	// Keep reports with test case executions which are assigned to a release.
+	KeepReleased bool
+	// Keep reports with reviewed test case executions.
+	KeepReviewed bool
+	// Number of reports which are deleted at the same time. Defaults to 4.
+	Workers int
+	// Interval to check the delete status. Defaults to 1 second.
+	PollInterval time.Duration
+	// Maximum time to wait for each delete task. Zero waits forever.
+	Timeout time.Duration
+	// Only select the reports without deleting them.
+	DryRun bool
+}
+
+// Checks if at least one of the criteria which restrict the selected reports is set.
+// An empty filter matches all reports, so it does not count.
+func (o *PruneOptions) hasCriteria() bool {
+	return !o.From.IsZero() || !o.To.IsZero() || o.OlderThan > 0 || len(o.Statuses) > 0 || o.TestPlanPattern != "" || (o.Filter != nil && !o.Filter.isEmpty())
+}
+
+////////////////////////////////////////////////////////////
+// PruneResult
+////////////////////////////////////////////////////////////
+
+// The result for a single report which was selected by PruneReports.
+type PruneResult struct {
+	Report *ReportHistoryItem
+	// The reason why the report was kept, empty if it was (or would be) deleted.
+	KeepReason PruneKeepReason
+	TaskID     string
+	Deleted    bool
+	Error      error
+}
+
+func (r *PruneResult) String() string {
+	return fmt.Sprintf("PruneResult(ReportID: %d, TestPlanName: %s, KeepReason: %s, Deleted: %t, Error: %v)",
+		r.Report.ReportID, r.Report.TestPlanName, r.KeepReason, r.Deleted, r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// Pruning
+////////////////////////////////////////////////////////////
+
+// The number of history items and report IDs which are requested at once.
+const prunePageSize = 100
+
+func (s *ReportManagementService) PruneReports(projectId int, options *PruneOptions) ([]*PruneResult, error) {
+	// Never select all reports of the project by accident
+	if options == nil || !options.hasCriteria() {
+		return nil, fmt.Errorf("at least one criterion to select the reports to prune is required")
+	}
+	from := options.From
+	if from.IsZero() {
+		from = time.Unix(0, 0).UTC()
+	}
+	to := options.To
+	if to.IsZero() {
+		to = time.Now()
+	}
+	history, err := s.getAllHistory(projectId, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	// Select the reports by their history data
+	results := []*PruneResult{}
+	resultsByReportId := map[int64]*PruneResult{}
+	cutoff := time.Now().Add(-options.OlderThan)
+	for _, item := range history {
+		if options.OlderThan > 0 && !item.ExecutionDate.Before(cutoff) {
+			continue
+		}
+		if len(options.Statuses) > 0 && !slices.Contains(options.Statuses, item.Status) {
+			continue
+		}
+		if options.TestPlanPattern != "" {
+			if matched, err := path.Match(options.TestPlanPattern, item.TestPlanName); err != nil {
+				return nil, fmt.Errorf("invalid test plan pattern: %w", err)
+			} else if !matched {
+				continue
+			}
+		}
+		result := &PruneResult{Report: item}
+		results = append(results, result)
+		resultsByReportId[item.ReportID] = result
+	}
+
+	// Select the reports by their test case executions
+	if options.Filter != nil && len(results) > 0 {
+		tces, err := s.getTestCaseExecutionsOfReports(projectId, options.Filter, reportIdsOf(results))
+		if err != nil {
+			return nil, err
+		}
+		matchingReportIds := map[int64]bool{}
+		for _, tce := range tces {
+			matchingReportIds[tce.ReportID] = true
+		}
+		results = slices.DeleteFunc(results, func(result *PruneResult) bool {
+			return !matchingReportIds[result.Report.ReportID]
+		})
+	}
+
+	// Keep the latest reports of each test plan, this considers all reports of the history
+	if options.KeepLast > 0 {
+		historyByTestPlan := map[string][]*ReportHistoryItem{}
+		for _, item := range history {
+			historyByTestPlan[item.TestPlanName] = append(historyByTestPlan[item.TestPlanName], item)
+		}
+		for _, items := range historyByTestPlan {
+			sort.SliceStable(items, func(i, j int) bool { return items[i].ExecutionDate.After(items[j].ExecutionDate) })
+			for _, item := range items[:min(options.KeepLast, len(items))] {
+				if result, ok := resultsByReportId[item.ReportID]; ok {
+					result.KeepReason = PRUNE_KEEP_REASON_LATEST
+				}
+			}
+		}
+	}
+
+	// Keep the reports which are linked to releases or reviews
+	if options.KeepReleased || options.KeepReviewed {
+		reportIds := []int64{}
+		for _, result := range results {
+			if result.KeepReason == "" {
+				reportIds = append(reportIds, result.Report.ReportID)
+			}
+		}
+		if len(reportIds) > 0 {
+			tces, err := s.getTestCaseExecutionsOfReports(projectId, &FilterParameters{}, reportIds)
+			if err != nil {
+				return nil, err
+			}
+			for _, tce := range tces {
+				result, ok := resultsByReportId[tce.ReportID]
+				if !ok || result.KeepReason != "" {
+					continue
+				}
+				if options.KeepReleased && len(tce.Releases) > 0 {
+					result.KeepReason = PRUNE_KEEP_REASON_RELEASED
+				} else if options.KeepReviewed && tce.LastReview != nil {
+					result.KeepReason = PRUNE_KEEP_REASON_REVIEWED
+				}
+			}
+		}
+	}
+
+	if options.DryRun {
+		return results, nil
+	}
+
+	// Delete the remaining reports
+	toDelete := []*PruneResult{}
+	for _, result := range results {
+		if result.KeepReason == "" {
+			toDelete = append(toDelete, result)
+		}
+	}
+	workers := options.Workers
+	if workers <= 0 {
+		workers = 4
+	}
+	pollInterval := options.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 1 * time.Second
+	}
+	forEachParallel(len(toDelete), workers, func(i int) {
+		result := toDelete[i]
+		task, _, err := s.DeleteReport(result.Report.ReportID)
+		if err != nil {
+			result.Error = fmt.Errorf("failed to delete report %d: %w", result.Report.ReportID, err)
+			return
+		}
+		result.TaskID = task.TaskID
+		if _, _, err := s.WaitForDelete(task.TaskID, pollInterval, options.Timeout); err != nil {
+			result.Error = err
+			return
+		}
+		result.Deleted = true
+	})
+	return results, nil
+}
+
+func (s *ReportManagementService) WaitForDelete(taskId string, pollInterval time.Duration, timeout time.Duration) (*DeleteStatus, *http.Response, error) {
+	var deadline time.Time
+	if timeout > 0 {
+		deadline = time.Now().Add(timeout)
+	}
+	for {
+		status, resp, err := s.GetDeleteStatus(taskId)
+		if err != nil {
+			return nil, resp, err
+		}
+		if status.IsDone() {
+			if !TaskStatus(status.Status).IsFinished() {
+				return status, resp, fmt.Errorf("delete task %s failed: %s", taskId, status.DetailedMessage)
+			}
+			return status, resp, nil
+		}
+		if !deadline.IsZero() && time.Now().Add(pollInterval).After(deadline) {
+			return status, resp, fmt.Errorf("timed out waiting for delete task %s", taskId)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+// Gets the complete report history by paging through the results.
+func (s *ReportManagementService) getAllHistory(projectId int, from time.Time, to time.Time) ([]*ReportHistoryItem, error) {
+	history := []*ReportHistoryItem{}
+	for offset := 0; ; offset += prunePageSize {
+		items, _, err := s.GetHistory(projectId, from, to, offset, prunePageSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get report history: %w", err)
+		}
+		history = append(history, items...)
+		if len(items) < prunePageSize {
+			return history, nil
+		}
+	}
+}
+
+// Gets the test case executions of the given reports which match the filter parameters.
+func (s *ReportManagementService) getTestCaseExecutionsOfReports(projectId int, filter *FilterParameters, reportIds []int64) ([]*TestCaseExecution, error) {
+	tces := []*TestCaseExecution{}
+	for chunk := range slices.Chunk(reportIds, prunePageSize) {
+		reportFilter := *filter
+		reportFilter.AtxIds = chunk
+		chunkTces, err := s.GetAllTestCaseExecutionsByFilter(projectId, &reportFilter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get test case executions: %w", err)
+		}
+		tces = append(tces, chunkTces...)
+	}
+	return tces, nil
+}
+
+func reportIdsOf(results []*PruneResult) []int64 {
+	ids := make([]int64, len(results))
+	for i, result := range results {
+		ids[i] = result.Report.ReportID
+	}
+	return ids
+}
@@ -0,0 +1,158 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Registers the handlers for the report history, the test case executions and deleting reports.
+func setupPrune(t *testing.T, assert *assert.Assertions) (*Client, *[]string) {
+	mux, client := setup(t)
+	old := time.Now().AddDate(0, 0, -60).UTC().Format(time.RFC3339)
+	recent := time.Now().AddDate(0, 0, -1).UTC().Format(time.RFC3339)
+	mux.HandleFunc("/api/report/reports/history", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		verifyHttpQueryParameter(assert, r, "projectId", "1")
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") != "0" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		fmt.Fprintf(w, `[
+			{"reportId": 1, "testPlanName": "Nightly", "status": "COMPLETE", "executionDate": "%s"},
+			{"reportId": 2, "testPlanName": "Nightly", "status": "COMPLETE", "executionDate": "%s"},
+			{"reportId": 3, "testPlanName": "Nightly", "status": "COMPLETE", "executionDate": "%s"},
+			{"reportId": 4, "testPlanName": "Nightly", "status": "COMPLETE_WITH_ERROR", "executionDate": "%s"},
+			{"reportId": 5, "testPlanName": "Release", "status": "COMPLETE", "executionDate": "%s"},
+			{"reportId": 6, "testPlanName": "Nightly", "status": "COMPLETE", "executionDate": "%s"}
+		]`, old, old, old, old, old, recent)
+	})
+	mux.HandleFunc("/api/report/testCaseExecutions/filter", func(w http.ResponseWriter, r *http.Request) {
+		var filter FilterParameters
+		assert.NoError(json.NewDecoder(r.Body).Decode(&filter))
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") != "0" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		tces := []*TestCaseExecution{
+			{ID: 10, ReportID: 1, Releases: []int64{7}},
+			{ID: 20, ReportID: 2, LastReview: &Review{Comment: "Known issue"}},
+			{ID: 30, ReportID: 3},
+			{ID: 40, ReportID: 4},
+			{ID: 50, ReportID: 5},
+		}
+		tces = slices.DeleteFunc(tces, func(tce *TestCaseExecution) bool {
+			return !slices.Contains(filter.AtxIds, tce.ReportID) || (len(filter.TestCaseName) > 0 && tce.ReportID == 3)
+		})
+		json.NewEncoder(w).Encode(tces)
+	})
+	var mutex sync.Mutex
+	deleted := []string{}
+	mux.HandleFunc("/api/report/reports/", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodDelete)
+		mutex.Lock()
+		deleted = append(deleted, r.URL.Path)
+		mutex.Unlock()
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"taskId": "delete%s"}`, r.URL.Path[len("/api/report/reports/"):])
+	})
+	mux.HandleFunc("/api/report/reports/deletestatus/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Path == "/api/report/reports/deletestatus/delete4" {
+			w.Write([]byte(`{"status": "error", "detailedMessage": "locked"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "FINISHED"}`))
+	})
+	return client, &deleted
+}
+
+func TestReportManagement_PruneReports(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	client, deleted := setupPrune(t, assert)
+
+	// Execute
+	results, err := client.ReportManagement.PruneReports(1, &PruneOptions{
+		OlderThan:       30 * 24 * time.Hour,
+		TestPlanPattern: "Night*",
+		KeepLast:        1,
+		KeepReleased:    true,
+		KeepReviewed:    true,
+		Workers:         2,
+		PollInterval:    time.Millisecond,
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	outcomes := map[int64]string{}
+	for _, result := range results {
+		switch {
+		case result.KeepReason != "":
+			outcomes[result.Report.ReportID] = string(result.KeepReason)
+		case result.Error != nil:
+			outcomes[result.Report.ReportID] = "ERROR"
+		case result.Deleted:
+			outcomes[result.Report.ReportID] = "DELETED"
+		}
+	}
+	assert.Equal(map[int64]string{
+		1: string(PRUNE_KEEP_REASON_RELEASED),
+		2: string(PRUNE_KEEP_REASON_REVIEWED),
+		3: "DELETED",
+		4: "ERROR",
+	}, outcomes, "Recent reports and other test plans should not be selected")
+	slices.Sort(*deleted)
+	assert.Equal([]string{"/api/report/reports/3", "/api/report/reports/4"}, *deleted)
+}
+
+func TestReportManagement_PruneReports_DryRun(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	client, deleted := setupPrune(t, assert)
+
+	// Execute
+	results, err := client.ReportManagement.PruneReports(1, &PruneOptions{
+		OlderThan: 30 * 24 * time.Hour,
+		Statuses:  []ReportStatus{REPORT_STATUS_COMPLETE},
+		Filter:    &FilterParameters{TestCaseName: []string{"Test"}},
+		KeepLast:  1,
+		DryRun:    true,
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Empty(*deleted, "Dry run should not delete reports")
+	outcomes := map[int64]PruneKeepReason{}
+	for _, result := range results {
+		outcomes[result.Report.ReportID] = result.KeepReason
+		assert.False(result.Deleted)
+	}
+	// Report 3 does not match the filter, report 4 has another status, report 6 is the latest but too young
+	assert.Equal(map[int64]PruneKeepReason{1: "", 2: "", 5: PRUNE_KEEP_REASON_LATEST}, outcomes)
+}
+
+func TestReportManagement_PruneReports_NoCriteria(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	client, deleted := setupPrune(t, assert)
+
+	// Execute
+	_, nilErr := client.ReportManagement.PruneReports(1, nil)
+	_, keepErr := client.ReportManagement.PruneReports(1, &PruneOptions{KeepLast: 1})
+	_, filterErr := client.ReportManagement.PruneReports(1, &PruneOptions{Filter: &FilterParameters{}})
+
+	// Verify
+	assert.Error(nilErr, "Should require criteria without options")
+	assert.Error(keepErr, "Should require criteria besides keep rules")
+	assert.Error(filterErr, "Should not count an empty filter as criterion")
+	assert.Empty(*deleted)
+}
@@ -0,0 +1,126 @@
+package gotestguide
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////
+// Releases
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) GetReleases(projectId int) ([]*Release, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/releases?projectId=%d", projectId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var responseObject = []*Release{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) GetRelease(releaseId int64) (*Release, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/releases/%d", releaseId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var responseObject = &Release{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) CreateRelease(projectId int, release *Release) (*Release, *http.Response, error) {
+	return s.sendRelease(http.MethodPost, fmt.Sprintf("api/report/releases?projectId=%d", projectId), release)
+}
+
+func (s *ReportManagementService) UpdateRelease(release *Release) (*Release, *http.Response, error) {
+	return s.sendRelease(http.MethodPut, fmt.Sprintf("api/report/releases/%d", release.ID), release)
+}
+
+func (s *ReportManagementService) CloseRelease(releaseId int64) (*Release, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("api/report/releases/%d/close", releaseId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var responseObject = &Release{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) AssignTestCaseExecutionsToRelease(releaseId int64, tceIds []int64) (*http.Response, error) {
+	return s.sendReleaseAssignment(http.MethodPost, releaseId, "testCaseExecutions", map[string][]int64{"tceIds": tceIds})
+}
+
+func (s *ReportManagementService) UnassignTestCaseExecutionsFromRelease(releaseId int64, tceIds []int64) (*http.Response, error) {
+	return s.sendReleaseAssignment(http.MethodDelete, releaseId, "testCaseExecutions", map[string][]int64{"tceIds": tceIds})
+}
+
+func (s *ReportManagementService) AssignReportsToRelease(releaseId int64, reportIds []int64) (*http.Response, error) {
+	return s.sendReleaseAssignment(http.MethodPost, releaseId, "reports", map[string][]int64{"reportIds": reportIds})
+}
+
+func (s *ReportManagementService) UnassignReportsFromRelease(releaseId int64, reportIds []int64) (*http.Response, error) {
+	return s.sendReleaseAssignment(http.MethodDelete, releaseId, "reports", map[string][]int64{"reportIds": reportIds})
+}
+
+func (s *ReportManagementService) GetReleaseProgress(releaseId int64) (*ReleaseProgress, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/releases/%d/progress", releaseId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var responseObject = &ReleaseProgress{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+// Sends the release as JSON body and decodes the returned release.
+func (s *ReportManagementService) sendRelease(method string, reqUrl string, release *Release) (*Release, *http.Response, error) {
+	// Prepare the body
+	bodyBytes, err := json.Marshal(release)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	// Prepare the request
+	req, err := s.client.NewRequest(method, reqUrl, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	var responseObject = &Release{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+// Sends the IDs of the objects which are assigned to or unassigned from a release.
+func (s *ReportManagementService) sendReleaseAssignment(method string, releaseId int64, objectType string, ids map[string][]int64) (*http.Response, error) {
+	bodyBytes, err := json.Marshal(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+	req, err := s.client.NewRequest(method, fmt.Sprintf("api/report/releases/%d/%s", releaseId, objectType), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return s.client.Do(req, nil)
+}
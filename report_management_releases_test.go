@@ -0,0 +1,159 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_GetReleases(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+
+	// Register a mock handler for the API endpoint
+	mux.HandleFunc("/api/report/releases", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		verifyHttpQueryParameter(assert, r, "projectId", "111")
+		w.WriteHeader(http.StatusOK)
+		writeMockResponse(t, w, "")
+	})
+
+	// Prepare the expected object
+	var expectedObject []*Release
+	getObjectFromMockResponse(t, "", &expectedObject)
+
+	// Execute
+	effectiveObject, resp, err := client.ReportManagement.GetReleases(111)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(http.StatusOK, resp.StatusCode, "Expected status code to be OK")
+	assert.Equal(expectedObject, effectiveObject, "Releases should match expected values")
+	assert.True(effectiveObject[0].Closed, "First release should be closed")
+	assert.Nil(effectiveObject[1].DueDate, "Missing dates should be nil")
+}
+
+func TestReportManagement_CreateRelease(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/releases", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "projectId", "111")
+		var received map[string]any
+		assert.NoError(json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal("Release 2.0", received["name"], "Name should be sent")
+		assert.NotContains(received, "id", "Empty ID should not be sent")
+		assert.NotContains(received, "dueDate", "Empty due date should not be sent")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 3, "projectId": 111, "name": "Release 2.0", "closed": false}`))
+	})
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.CreateRelease(111, &Release{Name: "Release 2.0"})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(int64(3), effectiveObject.ID, "Release ID should match expected value")
+}
+
+func TestReportManagement_UpdateRelease(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/releases/3", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPut)
+		var received Release
+		assert.NoError(json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal("2.0.1", received.Version, "Version should be sent")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 3, "name": "Release 2.0", "version": "2.0.1"}`))
+	})
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.UpdateRelease(&Release{ID: 3, Name: "Release 2.0", Version: "2.0.1"})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("2.0.1", effectiveObject.Version, "Version should match expected value")
+}
+
+func TestReportManagement_CloseRelease(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/releases/3/close", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 3, "name": "Release 2.0", "closed": true, "closedDate": "2025-04-01T08:00:00Z"}`))
+	})
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.CloseRelease(3)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.True(effectiveObject.Closed, "Release should be closed")
+	assert.NotNil(effectiveObject.ClosedDate, "Closed date should be set")
+}
+
+func TestReportManagement_AssignToRelease(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	received := map[string]map[string][]int64{}
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		var body map[string][]int64
+		assert.NoError(json.NewDecoder(r.Body).Decode(&body))
+		received[r.Method+" "+r.URL.Path] = body
+		w.WriteHeader(http.StatusNoContent)
+	}
+	mux.HandleFunc("/api/report/releases/3/testCaseExecutions", handler)
+	mux.HandleFunc("/api/report/releases/3/reports", handler)
+
+	// Execute
+	_, err1 := client.ReportManagement.AssignTestCaseExecutionsToRelease(3, []int64{10, 11})
+	_, err2 := client.ReportManagement.UnassignTestCaseExecutionsFromRelease(3, []int64{11})
+	_, err3 := client.ReportManagement.AssignReportsToRelease(3, []int64{5})
+	_, err4 := client.ReportManagement.UnassignReportsFromRelease(3, []int64{6})
+
+	// Verify
+	assert.NoError(err1)
+	assert.NoError(err2)
+	assert.NoError(err3)
+	assert.NoError(err4)
+	assert.Equal(map[string]map[string][]int64{
+		"POST /api/report/releases/3/testCaseExecutions":   {"tceIds": {10, 11}},
+		"DELETE /api/report/releases/3/testCaseExecutions": {"tceIds": {11}},
+		"POST /api/report/releases/3/reports":              {"reportIds": {5}},
+		"DELETE /api/report/releases/3/reports":            {"reportIds": {6}},
+	}, received, "Assignments should be sent")
+}
+
+func TestReportManagement_GetReleaseProgress(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+
+	// Register a mock handler for the API endpoint
+	mux.HandleFunc("/api/report/releases/2/progress", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		writeMockResponse(t, w, "")
+	})
+
+	// Prepare the expected object
+	var expectedObject *ReleaseProgress
+	getObjectFromMockResponse(t, "", &expectedObject)
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.GetReleaseProgress(2)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(expectedObject, effectiveObject, "Progress should match expected values")
+	assert.Equal(0.75, effectiveObject.PassRate(), "Pass rate should be calculated from the verdicts")
+}
@@ -0,0 +1,76 @@
+package gotestguide
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+////////////////////////////////////////////////////////////
+// BulkReviewResult
+////////////////////////////////////////////////////////////
+
+// The result of reviewing a single test case execution with CreateReviewsByFilter.
+type BulkReviewResult struct {
+	TceID  int64
+	Review *Review
+	Error  error
+}
+
+func (r *BulkReviewResult) String() string {
+	return fmt.Sprintf("BulkReviewResult(TceID: %d, Review: %v, Error: %v)", r.TceID, r.Review, r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// Reviews
+////////////////////////////////////////////////////////////
+
+func (s *ReportManagementService) CreateReview(tceId int64, review *Review) (*Review, *http.Response, error) {
+	// Prepare the body
+	bodyBytes, err := json.Marshal(review)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal object: %w", err)
+	}
+
+	// Prepare the request
+	req, err := s.client.NewRequest(http.MethodPost, fmt.Sprintf("api/report/testCaseExecution/%d/reviews", tceId), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	var responseObject = &Review{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) GetReviews(tceId int64) ([]*Review, *http.Response, error) {
+	req, err := s.client.NewRequest(http.MethodGet, fmt.Sprintf("api/report/testCaseExecution/%d/reviews", tceId), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	var responseObject = []*Review{}
+	resp, err := s.client.Do(req, &responseObject)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseObject, resp, nil
+}
+
+func (s *ReportManagementService) CreateReviewsByFilter(projectId int, filter *FilterParameters, review *Review, workers int) ([]*BulkReviewResult, error) {
+	tces, err := s.GetAllTestCaseExecutionsByFilter(projectId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get test case executions: %w", err)
+	}
+	results := make([]*BulkReviewResult, len(tces))
+	forEachParallel(len(tces), workers, func(i int) {
+		createdReview, _, err := s.CreateReview(tces[i].ID, review)
+		results[i] = &BulkReviewResult{TceID: tces[i].ID, Review: createdReview, Error: err}
+	})
+	return results, nil
+}
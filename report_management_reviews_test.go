@@ -0,0 +1,113 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportManagement_CreateReview(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecution/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		var received map[string]any
+		assert.NoError(json.NewDecoder(r.Body).Decode(&received))
+		assert.Equal("Known issue", received["comment"], "Comment should be sent")
+		assert.Equal("FAILED", received["verdict"], "Verdict should be sent")
+		assert.NotContains(received, "id", "Empty ID should not be sent")
+		assert.NotContains(received, "reviewDate", "Empty review date should not be sent")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 5, "comment": "Known issue", "verdict": "FAILED"}`))
+	})
+
+	// Execute
+	effectiveObject, resp, err := client.ReportManagement.CreateReview(1, &Review{
+		Comment: "Known issue",
+		Verdict: VERDICT_FAILED,
+		Tickets: []string{"PROJ-1"},
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(http.StatusOK, resp.StatusCode, "Expected status code to be OK")
+	assert.Equal(int64(5), effectiveObject.ID, "Review ID should match expected value")
+}
+
+func TestReportManagement_GetReviews(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+
+	// Register a mock handler for the API endpoint
+	mux.HandleFunc("/api/report/testCaseExecution/1/reviews", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		writeMockResponse(t, w, "")
+	})
+
+	// Prepare the expected object
+	var expectedObject []Review
+	getObjectFromMockResponse(t, "", &expectedObject)
+
+	// Execute
+	effectiveObject, resp, err := client.ReportManagement.GetReviews(1)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.NotNil(resp, "Response should not be nil")
+	assert.Equal(http.StatusOK, resp.StatusCode, "Expected status code to be OK")
+	assert.Len(effectiveObject, len(expectedObject), "Number of reviews should match")
+	for i := range expectedObject {
+		assert.Equal(expectedObject[i].ID, effectiveObject[i].ID, "Review ID should match expected value")
+		assert.Equal(expectedObject[i].Verdict, effectiveObject[i].Verdict, "Review Verdict should match expected value")
+		assert.Equal(expectedObject[i].Tickets, effectiveObject[i].Tickets, "Review Tickets should match expected value")
+	}
+}
+
+func TestReportManagement_CreateReviewsByFilter(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecutions/filter", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "offset", "0")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`[{"id": 10}, {"id": 11}, {"id": 12}]`))
+	})
+	var mutex sync.Mutex
+	reviewed := []string{}
+	mux.HandleFunc("/api/report/testCaseExecution/", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		mutex.Lock()
+		reviewed = append(reviewed, r.URL.Path)
+		mutex.Unlock()
+		if r.URL.Path == "/api/report/testCaseExecution/11/reviews" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": 1}`))
+	})
+
+	// Execute
+	results, err := client.ReportManagement.CreateReviewsByFilter(1, &FilterParameters{Verdicts: []Verdict{VERDICT_FAILED}}, &Review{Comment: "Triaged"}, 2)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(results, 3, "Should contain a result per test case execution")
+	assert.Len(reviewed, 3, "Should review all test case executions")
+	for i, result := range results {
+		assert.Equal(int64(10+i), result.TceID, "Results should be in the order of the test case executions")
+		if result.TceID == 11 {
+			assert.Error(result.Error, fmt.Sprintf("Review of %d should fail", result.TceID))
+		} else {
+			assert.NoError(result.Error, fmt.Sprintf("Review of %d should succeed", result.TceID))
+		}
+	}
+}
@@ -1,8 +1,16 @@
 package gotestguide
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"testing/fstest"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -119,3 +127,156 @@ func TestReportManagement_GetTestCaseExecutionsByProjectFilter(t *testing.T) {
 	assert.Equal(http.StatusOK, resp.StatusCode, "Expected status code to be OK")
 	assert.NotNil(effectiveObject, "Returned object should not be nil")
 }
+
+func TestReportManagement_UploadReportReader(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var receivedBody []byte
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "converterId", "junit")
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+
+	// Execute
+	task, _, err := client.ReportManagement.UploadReportReader(1, "junit", "results/junit.xml", strings.NewReader("<testsuites/>"))
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("task1", task.TaskID, "Task ID should match expected value")
+	assert.Equal([]string{"results/junit.xml"}, getArchiveEntries(t, receivedBody))
+}
+
+func TestReportManagement_UploadReportZip(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var receivedBody []byte
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	archive, err := createArchiveFromFS(fstest.MapFS{"report.atxml": {Data: []byte("<atx/>")}}, nil)
+	assert.NoError(err)
+
+	// Execute
+	_, _, err = client.ReportManagement.UploadReportZip(1, "ecu.test", bytes.NewReader(archive))
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(archive, receivedBody, "Archive should be sent as-is")
+}
+
+func TestReportManagement_WaitForUploadContext(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	var statusCalls atomic.Int32
+	mux.HandleFunc("/api/report/reports/uploadstatus/task1", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodGet)
+		w.WriteHeader(http.StatusOK)
+		if statusCalls.Add(1) < 3 {
+			w.Write([]byte(`{"status": "running"}`))
+			return
+		}
+		w.Write([]byte(`{"status": "FINISHED", "uploadResult": {"reportId": 100}}`))
+	})
+
+	// Execute
+	status, _, err := client.ReportManagement.WaitForUploadContext(context.Background(), "task1", time.Millisecond, 0)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.True(status.IsDone(), "Should accept the status in any case")
+	assert.Equal(100, status.UploadResult.ReportID)
+	assert.Equal(int32(3), statusCalls.Load())
+}
+
+func TestReportManagement_WaitForUploadContext_Canceled(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/reports/uploadstatus/task1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"status": "running"}`))
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	// Execute
+	status, _, err := client.ReportManagement.WaitForUploadContext(ctx, "task1", time.Hour, 0)
+
+	// Verify
+	assert.ErrorIs(err, context.DeadlineExceeded, "Should stop waiting when the context is done")
+	assert.Equal("running", status.Status)
+}
+
+func TestReportManagement_CreateFilter(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/filters", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "projectId", "2")
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		assert.NotContains(string(body), "filterId", "Filter ID should not be sent")
+		var received Filter
+		assert.NoError(json.Unmarshal(body, &received))
+		assert.Equal("Failed HIL tests", received.Name, "Filter name should be sent")
+		assert.Equal([]Verdict{VERDICT_FAILED}, received.Parameters.Verdicts, "Filter parameters should be sent")
+		received.FilterId = 7
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(received)
+	})
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.CreateFilter(2, &Filter{
+		Name:       "Failed HIL tests",
+		Parameters: &FilterParameters{Verdicts: []Verdict{VERDICT_FAILED}},
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(int64(7), effectiveObject.FilterId, "Filter ID should match expected value")
+}
+
+func TestReportManagement_UpdateFilter(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/filters/7", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPut)
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, r.Body)
+	})
+
+	// Execute
+	effectiveObject, _, err := client.ReportManagement.UpdateFilter(&Filter{FilterId: 7, Name: "Renamed"})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal("Renamed", effectiveObject.Name, "Filter name should match expected value")
+}
+
+func TestReportManagement_DeleteFilter(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/filters/7", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodDelete)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Execute
+	resp, err := client.ReportManagement.DeleteFilter(7)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(http.StatusNoContent, resp.StatusCode, "Expected status code to be No Content")
+}
@@ -0,0 +1,447 @@
+package gotestguide
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Error if the server could not be reached to replay the queued entries.
+var ErrServerUnreachable = errors.New("server unreachable")
+
+////////////////////////////////////////////////////////////
+// SpoolEntry
+////////////////////////////////////////////////////////////
+
+// An upload which is queued in an UploadSpool until the server is reachable again.
+type SpoolEntry struct {
+	// The ID of the entry, the entries are replayed in the order of their IDs.
+	ID        string         `json:"id"`
+	Type      SpoolEntryType `json:"type"`
+	CreatedAt time.Time      `json:"createdAt"`
+	// Name of the queued file (report archive, typed report or artifact) in the entry directory.
+	FileName    string `json:"fileName"`
+	ProjectID   int    `json:"projectId,omitempty"`
+	ConverterID string `json:"converterId,omitempty"`
+	// The OptionalReportIdentifier of a typed report, which lets the server detect double uploads.
+	ReportIdentifier string `json:"reportIdentifier,omitempty"`
+	TceID            int64  `json:"tceId,omitempty"`
+	Comment          string `json:"comment,omitempty"`
+	Category         string `json:"category,omitempty"`
+	// The error of the last replay if the entry failed.
+	Error string `json:"error,omitempty"`
+
+	dir string
+}
+
+func (e *SpoolEntry) String() string {
+	return fmt.Sprintf("SpoolEntry(ID: %s, Type: %s, CreatedAt: %s, FileName: %s, ProjectID: %d, TceID: %d, ReportIdentifier: %s, Error: %s)",
+		e.ID, e.Type, e.CreatedAt.Format(time.RFC3339), e.FileName, e.ProjectID, e.TceID, e.ReportIdentifier, e.Error)
+}
+
+// The path of the queued file.
+func (e *SpoolEntry) FilePath() string {
+	return filepath.Join(e.dir, spoolPayloadDir, e.FileName)
+}
+
+////////////////////////////////////////////////////////////
+// SpoolFlushResult
+////////////////////////////////////////////////////////////
+
+// The outcome of a single replayed entry.
+type SpoolFlushResult struct {
+	Entry *SpoolEntry
+	// The upload task of a replayed report.
+	TaskRef *TaskRef
+	Error   error
+}
+
+func (r *SpoolFlushResult) String() string {
+	return fmt.Sprintf("SpoolFlushResult(Entry: %s, TaskRef: %v, Error: %v)", r.Entry.ID, r.TaskRef, r.Error)
+}
+
+////////////////////////////////////////////////////////////
+// UploadSpool
+////////////////////////////////////////////////////////////
+
+// The layout of the spool directory.
+const (
+	spoolQueueDir   = "queue"
+	spoolFailedDir  = "failed"
+	spoolTmpDir     = "tmp"
+	spoolPayloadDir = "payload"
+	spoolEntryFile  = "entry.json"
+)
+
+// A disk-backed queue for uploads. Uploads are sent directly while the server is reachable,
+// otherwise they are stored in the directory and replayed in order with Flush.
+// Entries which the server rejects are moved aside, so they do not block the queue.
+// Only typed reports carry a report identifier, which lets the server detect a repeated upload.
+// The spool is safe for concurrent use, but only one process should flush it at a time.
+type UploadSpool struct {
+	service ReportManagementServiceInterface
+	dir     string
+	mutex   sync.Mutex
+}
+
+// Creates a spool in the directory which uploads with the given service, e.g. client.ReportManagement.
+func NewUploadSpool(service ReportManagementServiceInterface, dir string) (*UploadSpool, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("spool directory is required")
+	}
+	for _, subDir := range []string{spoolQueueDir, spoolFailedDir, spoolTmpDir} {
+		if err := os.MkdirAll(filepath.Join(dir, subDir), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create spool directory: %w", err)
+		}
+	}
+	return &UploadSpool{service: service, dir: dir}, nil
+}
+
+// Uploads a report file or queues it if the server is unreachable.
+// The returned entry is only set if the report was queued.
+// Converted reports have no report identifier, so a replay after a lost response may create the report twice.
+// Use UploadReportTyped for json2atx reports to avoid this.
+func (q *UploadSpool) UploadReport(projectId int, converterId string, reportPath string) (*TaskRef, *SpoolEntry, error) {
+	archive, err := createArchiveFromFiles("", []string{reportPath})
+	if err != nil {
+		return nil, nil, err
+	}
+	entry := &SpoolEntry{Type: SPOOL_ENTRY_TYPE_REPORT, FileName: "report.zip", ProjectID: projectId, ConverterID: converterId}
+	return q.submit(entry, archive, "")
+}
+
+// Uploads a typed report or queues it if the server is unreachable.
+// A report without OptionalReportIdentifier gets a random one, so that a replay after a lost response is detected as double upload.
+// Use UploadReport.DeriveIdentifier beforehand to also detect uploads of the same report from another run.
+func (q *UploadSpool) UploadReportTyped(projectId int, report *UploadReport) (*TaskRef, *SpoolEntry, error) {
+	report, err := withReportIdentifier(report)
+	if err != nil {
+		return nil, nil, err
+	}
+	reportBytes, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	entry := &SpoolEntry{Type: SPOOL_ENTRY_TYPE_TYPED_REPORT, FileName: "report.json", ProjectID: projectId, ReportIdentifier: report.OptionalReportIdentifier}
+	return q.submit(entry, reportBytes, "")
+}
+
+// Uploads a typed report together with the files it references (relative to baseDir) or queues it if the server is unreachable.
+// The report is queued as json2atx report archive, it gets a report identifier like with UploadReportTyped.
+func (q *UploadSpool) UploadReportTypedWithFiles(projectId int, report *UploadReport, baseDir string) (*TaskRef, *SpoolEntry, error) {
+	report, err := withReportIdentifier(report)
+	if err != nil {
+		return nil, nil, err
+	}
+	archive, err := createTypedReportArchive(report, baseDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	entry := &SpoolEntry{Type: SPOOL_ENTRY_TYPE_REPORT, FileName: "report.zip", ProjectID: projectId, ConverterID: "json2atx", ReportIdentifier: report.OptionalReportIdentifier}
+	return q.submit(entry, archive, "")
+}
+
+// Adds an artifact to a test case execution or queues it if the server is unreachable.
+// The returned entry is only set if the artifact was queued.
+// The server cannot detect a repeated artifact, so a replay after a lost response may add it twice.
+func (q *UploadSpool) AddArtifact(tceId int64, filePath string, comment string, category string) (*SpoolEntry, error) {
+	if _, err := os.Stat(filePath); err != nil {
+		return nil, fmt.Errorf("failed to access file %s: %w", filePath, err)
+	}
+	entry := &SpoolEntry{Type: SPOOL_ENTRY_TYPE_ARTIFACT, FileName: filepath.Base(filePath), TceID: tceId, Comment: comment, Category: category}
+	_, entry, err := q.submit(entry, nil, filePath)
+	return entry, err
+}
+
+// Gets the queued entries in the order in which they are replayed.
+func (q *UploadSpool) List() ([]*SpoolEntry, error) {
+	return q.readEntries(spoolQueueDir)
+}
+
+// Gets the entries which were rejected by the server during a replay.
+func (q *UploadSpool) ListFailed() ([]*SpoolEntry, error) {
+	return q.readEntries(spoolFailedDir)
+}
+
+// Replays the queued entries in order. Stops with ErrServerUnreachable if the server cannot be reached,
+// the remaining entries stay queued.
+func (q *UploadSpool) Flush() ([]*SpoolFlushResult, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	return q.flush()
+}
+
+func (q *UploadSpool) flush() ([]*SpoolFlushResult, error) {
+	entries, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+	results := []*SpoolFlushResult{}
+	for i, entry := range entries {
+		taskRef, err := q.send(entry)
+		if isUnreachableError(err) {
+			return results, fmt.Errorf("%w, %d entries remain queued: %w", ErrServerUnreachable, len(entries)-i, err)
+		}
+		results = append(results, &SpoolFlushResult{Entry: entry, TaskRef: taskRef, Error: err})
+		if err != nil {
+			entry.Error = err.Error()
+			if err := q.moveToFailed(entry); err != nil {
+				return results, err
+			}
+			continue
+		}
+		if err := os.RemoveAll(entry.dir); err != nil {
+			return results, fmt.Errorf("failed to remove spool entry %s: %w", entry.ID, err)
+		}
+	}
+	return results, nil
+}
+
+// Sends the upload directly or adds it to the queue if the server is unreachable.
+// While older entries are queued, they are replayed first to keep the order.
+// The payload is either the given data or the file at sourcePath.
+func (q *UploadSpool) submit(entry *SpoolEntry, data []byte, sourcePath string) (*TaskRef, *SpoolEntry, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	queued, err := q.List()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(queued) > 0 {
+		if _, err := q.flush(); err != nil {
+			if errors.Is(err, ErrServerUnreachable) {
+				return q.enqueue(entry, data, sourcePath)
+			}
+			return nil, nil, err
+		}
+	}
+
+	taskRef, err := q.sendDirect(entry, data, sourcePath)
+	if isUnreachableError(err) {
+		return q.enqueue(entry, data, sourcePath)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return taskRef, nil, nil
+}
+
+func (q *UploadSpool) sendDirect(entry *SpoolEntry, data []byte, sourcePath string) (*TaskRef, error) {
+	if sourcePath != "" {
+		return q.sendEntry(entry, sourcePath)
+	}
+	return q.sendEntryReader(entry, bytes.NewReader(data))
+}
+
+// Sends a queued entry.
+func (q *UploadSpool) send(entry *SpoolEntry) (*TaskRef, error) {
+	return q.sendEntry(entry, entry.FilePath())
+}
+
+func (q *UploadSpool) sendEntry(entry *SpoolEntry, path string) (*TaskRef, error) {
+	if entry.Type == SPOOL_ENTRY_TYPE_ARTIFACT {
+		_, err := q.service.AddArtifact(entry.TceID, path, entry.Comment, entry.Category)
+		return nil, err
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+	defer file.Close()
+	return q.sendEntryReader(entry, file)
+}
+
+func (q *UploadSpool) sendEntryReader(entry *SpoolEntry, reader io.Reader) (*TaskRef, error) {
+	switch entry.Type {
+	case SPOOL_ENTRY_TYPE_REPORT:
+		taskRef, _, err := q.service.UploadReportZip(entry.ProjectID, entry.ConverterID, reader)
+		return taskRef, err
+	case SPOOL_ENTRY_TYPE_TYPED_REPORT:
+		taskRef, _, err := q.service.UploadReportReader(entry.ProjectID, "json2atx", "report.json", reader)
+		return taskRef, err
+	}
+	return nil, fmt.Errorf("unknown spool entry type: %s", entry.Type)
+}
+
+// Writes the entry into a temporary directory and moves it into the queue, so that partially written entries are never replayed.
+func (q *UploadSpool) enqueue(entry *SpoolEntry, data []byte, sourcePath string) (*TaskRef, *SpoolEntry, error) {
+	tmpDir, err := os.MkdirTemp(filepath.Join(q.dir, spoolTmpDir), "entry-")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create spool entry: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	entry.CreatedAt = time.Now()
+	entry.dir = tmpDir
+	if err := os.Mkdir(filepath.Join(tmpDir, spoolPayloadDir), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create spool entry: %w", err)
+	}
+	if sourcePath != "" {
+		err = copyFile(sourcePath, entry.FilePath())
+	} else {
+		err = os.WriteFile(entry.FilePath(), data, 0o644)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to write spool entry: %w", err)
+	}
+
+	// Another process may add entries at the same time, so retry with the next ID if the directory already exists
+	for attempt := 0; attempt < 100; attempt++ {
+		id, err := q.nextID()
+		if err != nil {
+			return nil, nil, err
+		}
+		entry.ID = id
+		if err := writeJsonFile(filepath.Join(tmpDir, spoolEntryFile), entry); err != nil {
+			return nil, nil, err
+		}
+		target := filepath.Join(q.dir, spoolQueueDir, id)
+		if err := os.Rename(tmpDir, target); err != nil {
+			if _, statErr := os.Stat(target); statErr == nil {
+				continue
+			}
+			return nil, nil, fmt.Errorf("failed to queue spool entry: %w", err)
+		}
+		entry.dir = target
+		return nil, entry, nil
+	}
+	return nil, nil, fmt.Errorf("failed to find a free spool entry ID")
+}
+
+// Gets the ID after the highest ID of the queued and failed entries.
+func (q *UploadSpool) nextID() (string, error) {
+	highest := 0
+	for _, subDir := range []string{spoolQueueDir, spoolFailedDir} {
+		dirEntries, err := os.ReadDir(filepath.Join(q.dir, subDir))
+		if err != nil {
+			return "", fmt.Errorf("failed to read spool directory: %w", err)
+		}
+		for _, dirEntry := range dirEntries {
+			if number, err := strconv.Atoi(dirEntry.Name()); err == nil && number > highest {
+				highest = number
+			}
+		}
+	}
+	return fmt.Sprintf("%08d", highest+1), nil
+}
+
+func (q *UploadSpool) readEntries(subDir string) ([]*SpoolEntry, error) {
+	dirEntries, err := os.ReadDir(filepath.Join(q.dir, subDir))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spool directory: %w", err)
+	}
+	// The zero padded IDs are sorted by the directory listing
+	entries := []*SpoolEntry{}
+	for _, dirEntry := range dirEntries {
+		if !dirEntry.IsDir() {
+			continue
+		}
+		entryDir := filepath.Join(q.dir, subDir, dirEntry.Name())
+		entryBytes, err := os.ReadFile(filepath.Join(entryDir, spoolEntryFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read spool entry %s: %w", dirEntry.Name(), err)
+		}
+		entry := &SpoolEntry{}
+		if err := json.Unmarshal(entryBytes, entry); err != nil {
+			return nil, fmt.Errorf("failed to parse spool entry %s: %w", dirEntry.Name(), err)
+		}
+		entry.dir = entryDir
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+func (q *UploadSpool) moveToFailed(entry *SpoolEntry) error {
+	if err := writeJsonFile(filepath.Join(entry.dir, spoolEntryFile), entry); err != nil {
+		return err
+	}
+	target := filepath.Join(q.dir, spoolFailedDir, entry.ID)
+	if err := os.Rename(entry.dir, target); err != nil {
+		return fmt.Errorf("failed to move spool entry %s: %w", entry.ID, err)
+	}
+	entry.dir = target
+	return nil
+}
+
+// Checks if the error is caused by a server which could not be reached or is temporarily unavailable
+// instead of a response of the server. Certificate and URL errors are permanent, so they do not count.
+func isUnreachableError(err error) bool {
+	var statusErr *statusCodeError
+	if errors.As(err, &statusErr) {
+		switch statusErr.statusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) || errors.As(err, &invalidErr) {
+		return false
+	}
+	// Connection, DNS and timeout errors or a connection which was closed by the server
+	var netErr net.Error
+	return errors.As(urlErr.Err, &netErr) || errors.Is(urlErr.Err, io.EOF) || errors.Is(urlErr.Err, io.ErrUnexpectedEOF)
+}
+
+// Gets a copy of the report with a random identifier if it has none.
+func withReportIdentifier(report *UploadReport) (*UploadReport, error) {
+	if report.OptionalReportIdentifier != "" {
+		return report, nil
+	}
+	identifier, err := newReportIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	reportCopy := *report
+	reportCopy.OptionalReportIdentifier = identifier
+	return &reportCopy, nil
+}
+
+// Creates a random identifier for a report.
+func newReportIdentifier() (string, error) {
+	randomBytes := make([]byte, 16)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", fmt.Errorf("failed to create report identifier: %w", err)
+	}
+	return hex.EncodeToString(randomBytes), nil
+}
+
+func copyFile(sourcePath string, targetPath string) error {
+	source, err := os.Open(sourcePath)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	target, err := os.Create(targetPath)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(target, source); err != nil {
+		target.Close()
+		return err
+	}
+	return target.Close()
+}
@@ -0,0 +1,251 @@
+package gotestguide
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates a spool with a client whose server is not reachable.
+func setupUnreachableSpool(t *testing.T, dir string) *UploadSpool {
+	server := httptest.NewServer(http.NewServeMux())
+	server.Close()
+	client, err := NewClient(server.URL, "token")
+	if err != nil {
+		t.Fatalf("Failed to create client: %v", err)
+	}
+	spool, err := NewUploadSpool(client.ReportManagement, dir)
+	if err != nil {
+		t.Fatalf("Failed to create spool: %v", err)
+	}
+	return spool
+}
+
+// Reads the typed report from an uploaded archive.
+func readUploadedTypedReport(assert *assert.Assertions, r *http.Request) *UploadReport {
+	body, err := io.ReadAll(r.Body)
+	assert.NoError(err)
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	assert.NoError(err)
+	file, err := archive.Open("report.json")
+	assert.NoError(err)
+	defer file.Close()
+	report := &UploadReport{}
+	assert.NoError(json.NewDecoder(file).Decode(report))
+	return report
+}
+
+func TestUploadSpool_UploadReportTyped(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	identifiers := []string{}
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "converterId", "json2atx")
+		identifiers = append(identifiers, readUploadedTypedReport(assert, r).OptionalReportIdentifier)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	spool, err := NewUploadSpool(client.ReportManagement, t.TempDir())
+	assert.NoError(err)
+	report := &UploadReport{Name: "Report"}
+
+	// Execute
+	taskRef, entry, err := spool.UploadReportTyped(1, report)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Nil(entry, "Should not queue the report")
+	assert.Equal("task1", taskRef.TaskID)
+	assert.Len(identifiers, 1)
+	assert.NotEmpty(identifiers[0], "Should add an identifier")
+	assert.Empty(report.OptionalReportIdentifier, "Should not modify the given report")
+}
+
+func TestUploadSpool_Flush(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	dir := t.TempDir()
+	artifactPath := filepath.Join(t.TempDir(), "log.txt")
+	assert.NoError(os.WriteFile(artifactPath, []byte("log"), 0644))
+	reportPath := filepath.Join(t.TempDir(), "report.xml")
+	assert.NoError(os.WriteFile(reportPath, []byte("<a/>"), 0644))
+	offlineSpool := setupUnreachableSpool(t, dir)
+	_, typedEntry, err := offlineSpool.UploadReportTyped(1, &UploadReport{Name: "Report", OptionalReportIdentifier: "run-1"})
+	assert.NoError(err)
+	artifactEntry, err := offlineSpool.AddArtifact(10, artifactPath, "Bench log", "")
+	assert.NoError(err)
+	_, reportEntry, err := offlineSpool.UploadReport(1, "junit", reportPath)
+	assert.NoError(err)
+	assert.NoError(os.Remove(artifactPath), "The queued artifact should not depend on the original file")
+
+	mux, client := setup(t)
+	requests := []string{}
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		converterId := r.URL.Query().Get("converterId")
+		requests = append(requests, "report "+converterId)
+		if converterId == "json2atx" {
+			assert.Equal("run-1", readUploadedTypedReport(assert, r).OptionalReportIdentifier)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	mux.HandleFunc("/api/report/testCaseExecution/10/artifacts", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPut)
+		assert.NoError(r.ParseMultipartForm(1 << 20))
+		_, header, err := r.FormFile("file")
+		assert.NoError(err)
+		requests = append(requests, "artifact "+header.Filename)
+		w.WriteHeader(http.StatusOK)
+	})
+	spool, err := NewUploadSpool(client.ReportManagement, dir)
+	assert.NoError(err)
+
+	// Execute
+	queued, listErr := spool.List()
+	results, err := spool.Flush()
+
+	// Verify
+	assert.NoError(listErr)
+	assert.NoError(err, "Should not return an error")
+	assert.Len(queued, 3)
+	assert.Equal([]string{typedEntry.ID, artifactEntry.ID, reportEntry.ID}, []string{queued[0].ID, queued[1].ID, queued[2].ID})
+	assert.Equal([]string{"report json2atx", "artifact log.txt", "report junit"}, requests, "Should replay in order")
+	assert.Len(results, 3)
+	assert.Equal("task1", results[0].TaskRef.TaskID)
+	assert.NoError(results[1].Error)
+	assert.Error(results[2].Error, "Should fail for the rejected report")
+	remaining, err := spool.List()
+	assert.NoError(err)
+	assert.Empty(remaining)
+	failed, err := spool.ListFailed()
+	assert.NoError(err)
+	assert.Len(failed, 1)
+	assert.Equal(reportEntry.ID, failed[0].ID)
+	assert.NotEmpty(failed[0].Error)
+	assert.FileExists(failed[0].FilePath())
+}
+
+func TestUploadSpool_Flush_Unreachable(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	spool := setupUnreachableSpool(t, t.TempDir())
+	_, first, err := spool.UploadReportTyped(1, &UploadReport{Name: "First"})
+	assert.NoError(err)
+	_, second, err := spool.UploadReportTyped(1, &UploadReport{Name: "Second"})
+	assert.NoError(err)
+
+	// Execute
+	results, err := spool.Flush()
+
+	// Verify
+	assert.ErrorIs(err, ErrServerUnreachable)
+	assert.Empty(results)
+	assert.NotEmpty(first.ReportIdentifier)
+	assert.NotEqual(first.ReportIdentifier, second.ReportIdentifier)
+	assert.Less(first.ID, second.ID)
+	queued, err := spool.List()
+	assert.NoError(err)
+	assert.Len(queued, 2, "Should keep the entries queued")
+}
+
+func TestUploadSpool_UploadReportTypedWithFiles(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	dir := t.TempDir()
+	reportDir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(reportDir, "log.txt"), []byte("log"), 0644))
+	report := &UploadReport{Name: "Report", TestCases: []IAbstractUploadTestCase{&UploadTestCase{Name: "Test", Artifacts: []string{"log.txt"}}}}
+	_, entry, err := setupUnreachableSpool(t, dir).UploadReportTypedWithFiles(1, report, reportDir)
+	assert.NoError(err)
+
+	mux, client := setup(t)
+	var entries []string
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpQueryParameter(assert, r, "converterId", "json2atx")
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(err)
+		entries = getArchiveEntries(t, body)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"taskId": "task1"}`))
+	})
+	spool, err := NewUploadSpool(client.ReportManagement, dir)
+	assert.NoError(err)
+
+	// Execute
+	results, err := spool.Flush()
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.NotNil(entry, "Should queue the report")
+	assert.NotEmpty(entry.ReportIdentifier)
+	assert.Len(results, 1)
+	assert.Equal([]string{"log.txt", "report.json"}, entries, "Should replay the report with its files")
+}
+
+func TestUploadSpool_UploadReport_Unavailable(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		expectQueue bool
+	}{
+		{"ServiceUnavailable", http.StatusServiceUnavailable, true},
+		{"BadGateway", http.StatusBadGateway, true},
+		{"TooManyRequests", http.StatusTooManyRequests, true},
+		{"BadRequest", http.StatusBadRequest, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Prepare
+			assert := assert.New(t)
+			mux, client := setup(t)
+			mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+			})
+			spool, err := NewUploadSpool(client.ReportManagement, t.TempDir())
+			assert.NoError(err)
+
+			// Execute
+			_, entry, err := spool.UploadReportTyped(1, &UploadReport{Name: "Report"})
+
+			// Verify
+			if test.expectQueue {
+				assert.NoError(err, "Should not return an error")
+				assert.NotNil(entry, "Should queue the report")
+			} else {
+				assert.Error(err, "Should return the rejection")
+				assert.Nil(entry)
+			}
+		})
+	}
+}
+
+func TestUploadSpool_UploadReport_UntrustedCertificate(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	server := httptest.NewTLSServer(http.NewServeMux())
+	defer server.Close()
+	client, err := NewClient(server.URL, "token")
+	assert.NoError(err)
+	spool, err := NewUploadSpool(client.ReportManagement, t.TempDir())
+	assert.NoError(err)
+
+	// Execute
+	_, entry, err := spool.UploadReportTyped(1, &UploadReport{Name: "Report"})
+
+	// Verify
+	assert.Error(err, "Should not queue a report for a server with an untrusted certificate")
+	assert.Nil(entry)
+}
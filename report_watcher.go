@@ -0,0 +1,407 @@
+package gotestguide
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Notifies about changes in a directory, implemented per platform.
+type dirNotifier interface {
+	// A channel which receives a value after changes, it is closed when the notifier stops.
+	Events() <-chan struct{}
+	Close() error
+}
+
+////////////////////////////////////////////////////////////
+// ReportWatcherOptions
+////////////////////////////////////////////////////////////
+
+// Options of a ReportWatcher. ProjectID, ConverterID and Dir are required.
+type ReportWatcherOptions struct {
+	ProjectID   int
+	ConverterID string
+	// Directory where new report files are dropped. Subdirectories are ignored.
+	Dir string
+	// Glob patterns (path.Match syntax) of the report file names. Empty accepts all files except hidden ones.
+	Patterns []string
+	// Directories for successfully uploaded and failed reports. Default to the "done" and "failed" subdirectories.
+	DoneDir   string
+	FailedDir string
+	// Path of the file which stores the state of running uploads. Defaults to ".go-test-guide-watch.json" in the directory.
+	StateFile string
+	// Time a file must be unchanged before it is uploaded. Defaults to 5 seconds.
+	StableTime time.Duration
+	// Interval to scan the directory. Defaults to 10 seconds, file system notifications trigger additional scans.
+	PollInterval time.Duration
+	// Interval to check the upload status. Defaults to 1 second.
+	UploadPollInterval time.Duration
+	// Maximum time to wait for each upload task. Zero waits forever.
+	UploadTimeout time.Duration
+	// Only poll the directory instead of using file system notifications.
+	ForcePolling bool
+	// Called after each processed report.
+	OnResult func(result *ReportWatcherResult)
+}
+
+////////////////////////////////////////////////////////////
+// ReportWatcherResult
+////////////////////////////////////////////////////////////
+
+// The outcome of a single report file which was processed by a ReportWatcher.
+type ReportWatcherResult struct {
+	File           string
+	MovedTo        string
+	TaskID         string
+	ReportID       int
+	IsDoubleUpload bool
+	Error          error
+	// The server could not be reached, the file stays in the directory and is processed again with a later scan.
+	WillRetry bool
+}
+
+func (r *ReportWatcherResult) String() string {
+	return fmt.Sprintf("ReportWatcherResult(File: %s, MovedTo: %s, TaskID: %s, ReportID: %d, IsDoubleUpload: %t, Error: %v, WillRetry: %t)",
+		r.File, r.MovedTo, r.TaskID, r.ReportID, r.IsDoubleUpload, r.Error, r.WillRetry)
+}
+
+////////////////////////////////////////////////////////////
+// ReportWatcher
+////////////////////////////////////////////////////////////
+
+// The states of a report in the state file.
+const (
+	reportWatcherStatusUploading = "uploading"
+	reportWatcherStatusDone      = "done"
+	reportWatcherStatusFailed    = "failed"
+)
+
+// The persisted state of the reports which are processed, so that a restart does not upload them twice.
+type reportWatcherState struct {
+	Files map[string]*reportWatcherFileState `json:"files"`
+}
+
+type reportWatcherFileState struct {
+	Hash           string    `json:"hash"`
+	Status         string    `json:"status"`
+	TaskID         string    `json:"taskId,omitempty"`
+	ReportID       int       `json:"reportId,omitempty"`
+	IsDoubleUpload bool      `json:"isDoubleUpload,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	Time           time.Time `json:"time"`
+}
+
+// A candidate file which is waiting to become stable.
+type pendingReportFile struct {
+	size    int64
+	modTime time.Time
+	since   time.Time
+}
+
+// Watches a directory and uploads each new report file once it is stable.
+type ReportWatcher struct {
+	service ReportManagementServiceInterface
+	options ReportWatcherOptions
+	state   *reportWatcherState
+	pending map[string]*pendingReportFile
+}
+
+// Creates a watcher which uploads the report files dropped into a directory with the given service, e.g. client.ReportManagement.
+func NewReportWatcher(service ReportManagementServiceInterface, options *ReportWatcherOptions) (*ReportWatcher, error) {
+	if options == nil || options.ProjectID == 0 || options.ConverterID == "" || options.Dir == "" {
+		return nil, fmt.Errorf("project, converter and directory are required")
+	}
+	for _, pattern := range options.Patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid pattern %s: %w", pattern, err)
+		}
+	}
+	watcher := &ReportWatcher{service: service, options: *options, pending: map[string]*pendingReportFile{}}
+	if watcher.options.DoneDir == "" {
+		watcher.options.DoneDir = filepath.Join(options.Dir, "done")
+	}
+	if watcher.options.FailedDir == "" {
+		watcher.options.FailedDir = filepath.Join(options.Dir, "failed")
+	}
+	if watcher.options.StateFile == "" {
+		watcher.options.StateFile = filepath.Join(options.Dir, ".go-test-guide-watch.json")
+	}
+	if watcher.options.StableTime <= 0 {
+		watcher.options.StableTime = 5 * time.Second
+	}
+	if watcher.options.PollInterval <= 0 {
+		watcher.options.PollInterval = 10 * time.Second
+	}
+	if watcher.options.UploadPollInterval <= 0 {
+		watcher.options.UploadPollInterval = 1 * time.Second
+	}
+	return watcher, nil
+}
+
+// Watches the directory until the context is canceled.
+// Errors of single reports are passed to OnResult, only errors with the directory or the state file stop the watcher.
+func (w *ReportWatcher) Run(ctx context.Context) error {
+	for _, dir := range []string{w.options.DoneDir, w.options.FailedDir} {
+		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", dir, err)
+		}
+	}
+	if err := w.loadState(); err != nil {
+		return err
+	}
+
+	var events <-chan struct{}
+	if !w.options.ForcePolling {
+		if notifier, err := newDirNotifier(w.options.Dir); err == nil {
+			defer notifier.Close()
+			events = notifier.Events()
+		}
+	}
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-events:
+			if !ok {
+				// Continue with polling if the notifier stopped
+				events = nil
+			}
+		case <-timer.C:
+		}
+		if err := w.scan(ctx); err != nil {
+			return err
+		}
+		// Check the pending files more often so that they are uploaded soon after they are stable
+		wait := w.options.PollInterval
+		if len(w.pending) > 0 {
+			wait = min(wait, max(w.options.StableTime/4, 10*time.Millisecond))
+		}
+		timer.Reset(wait)
+	}
+}
+
+// Scans the directory once and processes all stable report files.
+func (w *ReportWatcher) scan(ctx context.Context) error {
+	entries, err := os.ReadDir(w.options.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read directory %s: %w", w.options.Dir, err)
+	}
+	now := time.Now()
+	seen := map[string]bool{}
+	for _, entry := range entries {
+		if ctx.Err() != nil {
+			return nil
+		}
+		name := entry.Name()
+		if !entry.Type().IsRegular() || !w.isReportFile(name) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The file was removed in the meantime
+			continue
+		}
+		seen[name] = true
+
+		// Files of the state were already stable, e.g. before a restart
+		if _, ok := w.state.Files[name]; ok {
+			if err := w.process(ctx, name); err != nil {
+				return err
+			}
+			continue
+		}
+		pending, ok := w.pending[name]
+		if !ok || pending.size != info.Size() || !pending.modTime.Equal(info.ModTime()) {
+			w.pending[name] = &pendingReportFile{size: info.Size(), modTime: info.ModTime(), since: now}
+			continue
+		}
+		if now.Sub(pending.since) >= w.options.StableTime {
+			delete(w.pending, name)
+			if err := w.process(ctx, name); err != nil {
+				return err
+			}
+		}
+	}
+	for name := range w.pending {
+		if !seen[name] {
+			delete(w.pending, name)
+		}
+	}
+	return nil
+}
+
+// Checks if the file name matches the patterns, hidden files are never reports.
+func (w *ReportWatcher) isReportFile(name string) bool {
+	if strings.HasPrefix(name, ".") {
+		return false
+	}
+	if len(w.options.Patterns) == 0 {
+		return true
+	}
+	for _, pattern := range w.options.Patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Uploads a stable report file (or continues with its state), waits for the upload and moves the file.
+// Only errors of the state file are returned, all other errors are part of the result.
+// If the server is unreachable or the context is canceled, the file is kept so that a later scan continues with it.
+func (w *ReportWatcher) process(ctx context.Context, name string) error {
+	filePath := filepath.Join(w.options.Dir, name)
+	result := &ReportWatcherResult{File: filePath}
+	hash, err := hashFile(filePath)
+	if err != nil {
+		// The file was removed or is not readable (yet), try again with the next scan
+		return nil
+	}
+
+	fileState := w.state.Files[name]
+	if fileState != nil && fileState.Hash != hash {
+		// Another file with the same name
+		fileState = nil
+	}
+	if fileState == nil {
+		fileState = &reportWatcherFileState{Hash: hash}
+		task, _, err := w.service.UploadReport(w.options.ProjectID, w.options.ConverterID, filePath)
+		if err != nil && isUnreachableError(err) {
+			w.notifyRetry(result, fmt.Errorf("failed to upload report: %w", err))
+			return nil
+		}
+		if err != nil {
+			fileState.Status = reportWatcherStatusFailed
+			fileState.Error = fmt.Sprintf("failed to upload report: %v", err)
+		} else {
+			fileState.Status = reportWatcherStatusUploading
+			fileState.TaskID = task.TaskID
+		}
+		fileState.Time = time.Now()
+		w.state.Files[name] = fileState
+		if err := w.saveState(); err != nil {
+			return err
+		}
+	}
+
+	if fileState.Status == reportWatcherStatusUploading {
+		status, _, err := w.service.WaitForUploadContext(ctx, fileState.TaskID, w.options.UploadPollInterval, w.options.UploadTimeout)
+		if ctx.Err() != nil {
+			// Stopped while waiting, the upload is continued after a restart
+			return nil
+		}
+		if err != nil && isUnreachableError(err) {
+			result.TaskID = fileState.TaskID
+			w.notifyRetry(result, err)
+			return nil
+		}
+		fileState.Status = reportWatcherStatusDone
+		if status != nil {
+			fileState.ReportID = status.UploadResult.ReportID
+			fileState.IsDoubleUpload = status.UploadResult.IsDoubleUpload
+		}
+		if err != nil {
+			fileState.Status = reportWatcherStatusFailed
+			fileState.Error = err.Error()
+		}
+		fileState.Time = time.Now()
+		if err := w.saveState(); err != nil {
+			return err
+		}
+	}
+
+	result.TaskID = fileState.TaskID
+	result.ReportID = fileState.ReportID
+	result.IsDoubleUpload = fileState.IsDoubleUpload
+	targetDir := w.options.DoneDir
+	if fileState.Status == reportWatcherStatusFailed {
+		targetDir = w.options.FailedDir
+		result.Error = errors.New(fileState.Error)
+	}
+	movedTo, err := moveToDir(filePath, targetDir)
+	if err != nil {
+		result.Error = errors.Join(result.Error, err)
+	} else {
+		result.MovedTo = movedTo
+		delete(w.state.Files, name)
+		if err := w.saveState(); err != nil {
+			return err
+		}
+	}
+	if w.options.OnResult != nil {
+		w.options.OnResult(result)
+	}
+	return nil
+}
+
+// Reports a temporary error of a file which stays in the directory.
+func (w *ReportWatcher) notifyRetry(result *ReportWatcherResult, err error) {
+	result.Error = err
+	result.WillRetry = true
+	if w.options.OnResult != nil {
+		w.options.OnResult(result)
+	}
+}
+
+func (w *ReportWatcher) loadState() error {
+	w.state = &reportWatcherState{Files: map[string]*reportWatcherFileState{}}
+	fileBytes, err := os.ReadFile(w.options.StateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := json.Unmarshal(fileBytes, w.state); err != nil {
+		return fmt.Errorf("failed to parse state file: %w", err)
+	}
+	if w.state.Files == nil {
+		w.state.Files = map[string]*reportWatcherFileState{}
+	}
+	return nil
+}
+
+// Writes the state to a temporary file first, so that a crash does not leave a corrupt state file.
+func (w *ReportWatcher) saveState() error {
+	tempFile := w.options.StateFile + ".tmp"
+	if err := writeJsonFile(tempFile, w.state); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tempFile, w.options.StateFile); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// Moves the file into the directory, adding a timestamp to the name if the target already exists.
+func moveToDir(filePath string, dir string) (string, error) {
+	name := filepath.Base(filePath)
+	target := filepath.Join(dir, name)
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(name)
+		target = filepath.Join(dir, fmt.Sprintf("%s-%s%s", strings.TrimSuffix(name, ext), time.Now().Format("20060102T150405.000"), ext))
+	}
+	if err := os.Rename(filePath, target); err != nil {
+		return "", fmt.Errorf("failed to move %s: %w", filePath, err)
+	}
+	return target, nil
+}
+
+// Calculates the SHA-256 hash of a file.
+func hashFile(filePath string) (string, error) {
+	hashes, err := calculateFileHashes(filePath, sha256.New)
+	if err != nil {
+		return "", err
+	}
+	return hashes[0], nil
+}
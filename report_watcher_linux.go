@@ -0,0 +1,58 @@
+//go:build linux
+
+package gotestguide
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Notifies about changes in a directory with inotify.
+type inotifyDirNotifier struct {
+	file   *os.File
+	events chan struct{}
+}
+
+// Creates a notifier for the changes of the files directly in the directory.
+func newDirNotifier(dir string) (dirNotifier, error) {
+	// A non-blocking descriptor is handled by the runtime poller, so closing the file stops a pending read
+	fd, err := syscall.InotifyInit1(syscall.IN_CLOEXEC | syscall.IN_NONBLOCK)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize inotify: %w", err)
+	}
+	mask := uint32(syscall.IN_CREATE | syscall.IN_CLOSE_WRITE | syscall.IN_MODIFY | syscall.IN_MOVED_TO)
+	if _, err := syscall.InotifyAddWatch(fd, dir, mask); err != nil {
+		syscall.Close(fd)
+		return nil, fmt.Errorf("failed to watch %s: %w", dir, err)
+	}
+	notifier := &inotifyDirNotifier{
+		file:   os.NewFile(uintptr(fd), "inotify"),
+		events: make(chan struct{}, 1),
+	}
+	go notifier.readEvents()
+	return notifier, nil
+}
+
+func (n *inotifyDirNotifier) readEvents() {
+	defer close(n.events)
+	buf := make([]byte, 4096)
+	for {
+		// The events are not parsed, every change triggers a new scan of the directory
+		if _, err := n.file.Read(buf); err != nil {
+			return
+		}
+		select {
+		case n.events <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (n *inotifyDirNotifier) Events() <-chan struct{} {
+	return n.events
+}
+
+func (n *inotifyDirNotifier) Close() error {
+	return n.file.Close()
+}
@@ -0,0 +1,10 @@
+//go:build !linux
+
+package gotestguide
+
+import "errors"
+
+// File system notifications are only implemented for Linux, the watcher polls the directory on other platforms.
+func newDirNotifier(dir string) (dirNotifier, error) {
+	return nil, errors.New("file system notifications are not supported on this platform")
+}
@@ -0,0 +1,174 @@
+package gotestguide
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Runs the watcher until the expected number of results is reached or the test times out.
+func runReportWatcher(t *testing.T, client *Client, options *ReportWatcherOptions, expectedResults int) []*ReportWatcherResult {
+	var mutex sync.Mutex
+	results := []*ReportWatcherResult{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	options.OnResult = func(result *ReportWatcherResult) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		results = append(results, result)
+		if len(results) == expectedResults {
+			cancel()
+		}
+	}
+	watcher, err := NewReportWatcher(client.ReportManagement, options)
+	assert.NoError(t, err, "Should not return an error")
+	assert.NoError(t, watcher.Run(ctx), "Should not return an error")
+	assert.NotErrorIs(t, ctx.Err(), context.DeadlineExceeded, "Should process all reports before the timeout")
+	mutex.Lock()
+	defer mutex.Unlock()
+	return results
+}
+
+func setupReportWatcher(t *testing.T, assert *assert.Assertions) (*Client, *atomic.Int32) {
+	mux, client := setup(t)
+	var uploads atomic.Int32
+	mux.HandleFunc("/api/report/reports", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		verifyHttpQueryParameter(assert, r, "converterId", "junit")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"taskId": "task%d"}`, uploads.Add(1))
+	})
+	mux.HandleFunc("/api/report/reports/uploadstatus/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		switch filepath.Base(r.URL.Path) {
+		case "task1":
+			w.Write([]byte(`{"status": "finished", "uploadResult": {"reportId": 100}}`))
+		default:
+			w.Write([]byte(`{"status": "finished", "uploadResult": {"reportId": 0, "resultMessages": ["Invalid report"]}}`))
+		}
+	})
+	return client, &uploads
+}
+
+func TestReportWatcher_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		forcePolling bool
+		pollInterval time.Duration
+	}{
+		// Only the notifications can trigger the scans within the test timeout
+		{"Notifications", false, time.Hour},
+		{"Polling", true, 20 * time.Millisecond},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Prepare
+			assert := assert.New(t)
+			client, uploads := setupReportWatcher(t, assert)
+			dir := t.TempDir()
+			assert.NoError(os.WriteFile(filepath.Join(dir, "a.xml"), []byte("<a/>"), 0644))
+			assert.NoError(os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte("text"), 0644))
+			go func() {
+				// Dropped after the watcher started
+				time.Sleep(100 * time.Millisecond)
+				os.WriteFile(filepath.Join(dir, "b.xml"), []byte("<b/>"), 0644)
+			}()
+
+			// Execute
+			results := runReportWatcher(t, client, &ReportWatcherOptions{
+				ProjectID:          1,
+				ConverterID:        "junit",
+				Dir:                dir,
+				Patterns:           []string{"*.xml"},
+				StableTime:         50 * time.Millisecond,
+				PollInterval:       test.pollInterval,
+				UploadPollInterval: time.Millisecond,
+				ForcePolling:       test.forcePolling,
+			}, 2)
+
+			// Verify
+			assert.Len(results, 2)
+			assert.Equal(int32(2), uploads.Load())
+			assert.Equal(filepath.Join(dir, "a.xml"), results[0].File)
+			assert.NoError(results[0].Error)
+			assert.Equal(100, results[0].ReportID)
+			assert.FileExists(filepath.Join(dir, "done", "a.xml"))
+			assert.Error(results[1].Error, "The second upload should fail")
+			assert.FileExists(filepath.Join(dir, "failed", "b.xml"))
+			assert.FileExists(filepath.Join(dir, "ignored.txt"))
+			assert.NoFileExists(filepath.Join(dir, "a.xml"))
+		})
+	}
+}
+
+func TestReportWatcher_Run_ResumeFromState(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	client, uploads := setupReportWatcher(t, assert)
+	dir := t.TempDir()
+	reportPath := filepath.Join(dir, "a.xml")
+	assert.NoError(os.WriteFile(reportPath, []byte("<a/>"), 0644))
+	hash, err := hashFile(reportPath)
+	assert.NoError(err)
+	// The watcher was stopped while waiting for the upload
+	state := &reportWatcherState{Files: map[string]*reportWatcherFileState{
+		"a.xml": {Hash: hash, Status: reportWatcherStatusUploading, TaskID: "task1"},
+	}}
+	assert.NoError(writeJsonFile(filepath.Join(dir, ".go-test-guide-watch.json"), state))
+
+	// Execute
+	results := runReportWatcher(t, client, &ReportWatcherOptions{
+		ProjectID:          1,
+		ConverterID:        "junit",
+		Dir:                dir,
+		StableTime:         time.Hour,
+		UploadPollInterval: time.Millisecond,
+	}, 1)
+
+	// Verify
+	assert.Len(results, 1)
+	assert.Equal(int32(0), uploads.Load(), "Should not upload the report again")
+	assert.Equal("task1", results[0].TaskID)
+	assert.Equal(100, results[0].ReportID)
+	assert.FileExists(filepath.Join(dir, "done", "a.xml"))
+	stateBytes, err := os.ReadFile(filepath.Join(dir, ".go-test-guide-watch.json"))
+	assert.NoError(err)
+	assert.JSONEq(`{"files": {}}`, string(stateBytes), "Should remove the moved file from the state")
+}
+
+func TestReportWatcher_Run_Unreachable(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	server := httptest.NewServer(http.NewServeMux())
+	server.Close()
+	client, err := NewClient(server.URL, "token")
+	assert.NoError(err)
+	dir := t.TempDir()
+	assert.NoError(os.WriteFile(filepath.Join(dir, "a.xml"), []byte("<a/>"), 0644))
+
+	// Execute
+	results := runReportWatcher(t, client, &ReportWatcherOptions{
+		ProjectID:    1,
+		ConverterID:  "junit",
+		Dir:          dir,
+		StableTime:   time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+		ForcePolling: true,
+	}, 2)
+
+	// Verify
+	assert.Len(results, 2, "Should retry the upload")
+	assert.True(results[0].WillRetry)
+	assert.Error(results[0].Error)
+	assert.FileExists(filepath.Join(dir, "a.xml"), "Should keep the report for the next scan")
+	assert.NoFileExists(filepath.Join(dir, "failed", "a.xml"))
+}
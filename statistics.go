@@ -0,0 +1,183 @@
+package gotestguide
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+////////////////////////////////////////////////////////////
+// ExecutionStatistics
+////////////////////////////////////////////////////////////
+
+// Aggregated values of a set of test case executions.
+type ExecutionStatistics struct {
+	Total int `json:"total"`
+	// The number of executions per effective verdict.
+	Verdicts map[Verdict]int `json:"verdicts"`
+	// The share of executions with a PASSED effective verdict between 0 and 1.
+	PassRate float64 `json:"passRate"`
+	// The mean execution time in seconds.
+	MeanExecutionTime float64 `json:"meanExecutionTime"`
+	// The 95th percentile of the execution time in seconds.
+	P95ExecutionTime float64 `json:"p95ExecutionTime"`
+}
+
+func (s *ExecutionStatistics) String() string {
+	return fmt.Sprintf("ExecutionStatistics(Total: %d, PassRate: %.3f, MeanExecutionTime: %.1f, P95ExecutionTime: %.1f)",
+		s.Total, s.PassRate, s.MeanExecutionTime, s.P95ExecutionTime)
+}
+
+////////////////////////////////////////////////////////////
+// StatisticsBucket
+////////////////////////////////////////////////////////////
+
+// The statistics of the executions in one interval of a time series.
+type StatisticsBucket struct {
+	Start time.Time `json:"start"`
+	ExecutionStatistics
+}
+
+func (b *StatisticsBucket) String() string {
+	return fmt.Sprintf("StatisticsBucket(Start: %s, Total: %d, PassRate: %.3f)", b.Start.Format(time.DateOnly), b.Total, b.PassRate)
+}
+
+////////////////////////////////////////////////////////////
+// TestCaseExecutionStatistics
+////////////////////////////////////////////////////////////
+
+// The statistics of a set of test case executions with a time series and value counts.
+type TestCaseExecutionStatistics struct {
+	ExecutionStatistics
+	Interval StatisticsInterval `json:"interval"`
+	// One bucket per interval from the first to the last execution, including empty intervals.
+	Series []*StatisticsBucket `json:"series"`
+	// The number of executions per test environment key and value.
+	TestEnvironments map[string]map[string]int `json:"testEnvironments"`
+	// The number of executions per attribute key and value.
+	Attributes map[string]map[string]int `json:"attributes"`
+}
+
+func (s *TestCaseExecutionStatistics) String() string {
+	return fmt.Sprintf("TestCaseExecutionStatistics(Total: %d, PassRate: %.3f, Interval: %s, Buckets: %d)",
+		s.Total, s.PassRate, s.Interval, len(s.Series))
+}
+
+////////////////////////////////////////////////////////////
+// Aggregation
+////////////////////////////////////////////////////////////
+
+// Computes the statistics of the test case executions with a time series in the given interval.
+// Intervals start at midnight UTC, weeks start on Monday. Executions without timestamp are not part of the time series.
+func ComputeStatistics(tces []*TestCaseExecution, interval StatisticsInterval) (*TestCaseExecutionStatistics, error) {
+	if interval != STATISTICS_INTERVAL_DAY && interval != STATISTICS_INTERVAL_WEEK {
+		return nil, fmt.Errorf("unknown statistics interval '%s'", interval)
+	}
+	stats := &TestCaseExecutionStatistics{
+		ExecutionStatistics: computeExecutionStatistics(tces),
+		Interval:            interval,
+		Series:              []*StatisticsBucket{},
+		TestEnvironments:    map[string]map[string]int{},
+		Attributes:          map[string]map[string]int{},
+	}
+
+	// Count the values
+	for _, tce := range tces {
+		for _, env := range tce.TestEnvironments {
+			countValue(stats.TestEnvironments, env.Key, env.Value)
+		}
+		for _, attribute := range tce.Attributes {
+			if attribute.Value != "" {
+				countValue(stats.Attributes, attribute.Key, attribute.Value)
+			}
+			for _, value := range attribute.Values {
+				countValue(stats.Attributes, attribute.Key, value)
+			}
+		}
+	}
+
+	// Create the time series
+	tcesByStart := map[time.Time][]*TestCaseExecution{}
+	var first, last time.Time
+	for _, tce := range tces {
+		if tce.ExecutionTimestamp.IsZero() {
+			continue
+		}
+		start := intervalStart(tce.ExecutionTimestamp, interval)
+		tcesByStart[start] = append(tcesByStart[start], tce)
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if last.IsZero() || start.After(last) {
+			last = start
+		}
+	}
+	if !first.IsZero() {
+		for start := first; !start.After(last); start = nextIntervalStart(start, interval) {
+			stats.Series = append(stats.Series, &StatisticsBucket{
+				Start:               start,
+				ExecutionStatistics: computeExecutionStatistics(tcesByStart[start]),
+			})
+		}
+	}
+	return stats, nil
+}
+
+func computeExecutionStatistics(tces []*TestCaseExecution) ExecutionStatistics {
+	stats := ExecutionStatistics{Total: len(tces), Verdicts: CountVerdicts(tces)}
+	if len(tces) == 0 {
+		return stats
+	}
+	stats.PassRate = float64(stats.Verdicts[VERDICT_PASSED]) / float64(len(tces))
+	times := make([]int, len(tces))
+	sum := 0
+	for i, tce := range tces {
+		times[i] = tce.ExecutionTime
+		sum += tce.ExecutionTime
+	}
+	stats.MeanExecutionTime = float64(sum) / float64(len(tces))
+	stats.P95ExecutionTime = float64(percentile(times, 95))
+	return stats
+}
+
+// Gets the percentile with the nearest-rank method.
+func percentile(values []int, p float64) int {
+	sorted := append([]int{}, values...)
+	sort.Ints(sorted)
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[max(rank, 1)-1]
+}
+
+func countValue(counts map[string]map[string]int, key string, value string) {
+	if counts[key] == nil {
+		counts[key] = map[string]int{}
+	}
+	counts[key][value]++
+}
+
+func intervalStart(t time.Time, interval StatisticsInterval) time.Time {
+	t = t.UTC()
+	start := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	if interval == STATISTICS_INTERVAL_WEEK {
+		daysSinceMonday := (int(start.Weekday()) + 6) % 7
+		start = start.AddDate(0, 0, -daysSinceMonday)
+	}
+	return start
+}
+
+func nextIntervalStart(start time.Time, interval StatisticsInterval) time.Time {
+	if interval == STATISTICS_INTERVAL_WEEK {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// Computes the statistics of all test case executions matching the filter parameters.
+func (s *ReportManagementService) GetStatistics(projectId int, filter *FilterParameters, interval StatisticsInterval) (*TestCaseExecutionStatistics, error) {
+	tces, err := s.GetAllTestCaseExecutionsByFilter(projectId, filter)
+	if err != nil {
+		return nil, err
+	}
+	return ComputeStatistics(tces, interval)
+}
@@ -0,0 +1,103 @@
+package gotestguide
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getStatisticsTestCaseExecutions() []*TestCaseExecution {
+	// 2025-01-06 is a Monday
+	monday := time.Date(2025, 1, 6, 8, 0, 0, 0, time.UTC)
+	return []*TestCaseExecution{
+		{Verdict: VERDICT_PASSED, ExecutionTime: 10, ExecutionTimestamp: monday,
+			TestEnvironments: []*TestEnvironment{{Key: "Bench", Value: "HIL1"}},
+			Attributes:       []*Attribute{{Key: "ECU", Value: "v1"}}},
+		{Verdict: VERDICT_FAILED, ExecutionTime: 20, ExecutionTimestamp: monday.Add(2 * time.Hour),
+			TestEnvironments: []*TestEnvironment{{Key: "Bench", Value: "HIL2"}},
+			Attributes:       []*Attribute{{Key: "ECU", Values: []string{"v1", "v2"}}}},
+		{Verdict: VERDICT_FAILED, EffectiveVerdict: VERDICT_PASSED, ExecutionTime: 30, ExecutionTimestamp: monday.AddDate(0, 0, 2),
+			TestEnvironments: []*TestEnvironment{{Key: "Bench", Value: "HIL1"}}},
+		{Verdict: VERDICT_ERROR, ExecutionTime: 100, ExecutionTimestamp: monday.AddDate(0, 0, 7)},
+	}
+}
+
+func TestComputeStatistics_Day(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	tces := getStatisticsTestCaseExecutions()
+
+	// Execute
+	stats, err := ComputeStatistics(tces, STATISTICS_INTERVAL_DAY)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(4, stats.Total)
+	assert.Equal(map[Verdict]int{VERDICT_PASSED: 2, VERDICT_FAILED: 1, VERDICT_ERROR: 1}, stats.Verdicts, "Effective verdicts should be counted")
+	assert.Equal(0.5, stats.PassRate)
+	assert.Equal(40.0, stats.MeanExecutionTime)
+	assert.Equal(100.0, stats.P95ExecutionTime)
+	assert.Equal(map[string]map[string]int{"Bench": {"HIL1": 2, "HIL2": 1}}, stats.TestEnvironments)
+	assert.Equal(map[string]map[string]int{"ECU": {"v1": 2, "v2": 1}}, stats.Attributes)
+
+	assert.Len(stats.Series, 8, "Should contain a bucket for each day including empty days")
+	assert.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), stats.Series[0].Start)
+	assert.Equal(2, stats.Series[0].Total)
+	assert.Equal(0.5, stats.Series[0].PassRate)
+	assert.Equal(15.0, stats.Series[0].MeanExecutionTime)
+	assert.Equal(20.0, stats.Series[0].P95ExecutionTime)
+	assert.Equal(0, stats.Series[1].Total, "Empty days should be included")
+	assert.Equal(1.0, stats.Series[2].PassRate)
+	assert.Equal(0.0, stats.Series[7].PassRate)
+}
+
+func TestComputeStatistics_Week(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	tces := getStatisticsTestCaseExecutions()
+	// A Sunday belongs to the week of the previous Monday
+	tces[2].ExecutionTimestamp = time.Date(2025, 1, 12, 23, 0, 0, 0, time.UTC)
+
+	// Execute
+	stats, err := ComputeStatistics(tces, STATISTICS_INTERVAL_WEEK)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(stats.Series, 2)
+	assert.Equal(time.Date(2025, 1, 6, 0, 0, 0, 0, time.UTC), stats.Series[0].Start)
+	assert.Equal(3, stats.Series[0].Total)
+	assert.Equal(time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC), stats.Series[1].Start)
+	assert.Equal(1, stats.Series[1].Total)
+
+	_, err = ComputeStatistics(tces, "month")
+	assert.Error(err, "Unknown intervals should return an error")
+}
+
+func TestReportManagement_GetStatistics(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	mux, client := setup(t)
+	mux.HandleFunc("/api/report/testCaseExecutions/filter", func(w http.ResponseWriter, r *http.Request) {
+		verifyHttpMethod(assert, r, http.MethodPost)
+		w.WriteHeader(http.StatusOK)
+		if r.URL.Query().Get("offset") != "0" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`[
+			{"id": 1, "verdict": "PASSED", "executionTime": 5, "executionTimestamp": "2025-01-06T10:00:00+02:00"},
+			{"id": 2, "verdict": "FAILED", "executionTime": 7, "executionTimestamp": "2025-01-06T12:00:00Z"}
+		]`))
+	})
+
+	// Execute
+	stats, err := client.ReportManagement.GetStatistics(1, nil, STATISTICS_INTERVAL_DAY)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(2, stats.Total)
+	assert.Len(stats.Series, 1, "Executions in different time zones should be in the same bucket")
+	assert.Equal(0.5, stats.Series[0].PassRate)
+}
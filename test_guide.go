@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"sync"
 )
 
 // Error for 404 not found responses.
@@ -23,9 +24,14 @@ func Ptr[T any](v T) *T {
 
 // A client to interact with the test.guide API.
 type Client struct {
-	baseUrl *url.URL
-	authKey string
-	debug   bool
+	baseUrl    *url.URL
+	authKey    string
+	debug      bool
+	httpClient *http.Client
+	transport  *http.Transport
+
+	uploadLimiter *requestLimiter
+	queryLimiter  *requestLimiter
 
 	// API for up- and download of artifacts to/from test.guide.
 	Artifacts ArtifactsServiceInterface
@@ -38,14 +44,23 @@ type Client struct {
 }
 
 // Create a new client for the test.guide API.
-func NewClient(baseUrl, authKey string) (*Client, error) {
+// Additional options can be passed to configure the underlying HTTP connection (TLS, proxy, ...).
+func NewClient(baseUrl, authKey string, options ...ClientOption) (*Client, error) {
 	parsedUrl, err := url.Parse(baseUrl)
 	if err != nil {
 		return nil, fmt.Errorf("invalid base URL: %w", err)
 	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
 	client := &Client{
-		baseUrl: parsedUrl,
-		authKey: authKey,
+		baseUrl:    parsedUrl,
+		authKey:    authKey,
+		httpClient: &http.Client{Transport: transport},
+		transport:  transport,
+	}
+	for _, option := range options {
+		if err := option(client); err != nil {
+			return nil, err
+		}
 	}
 	client.Artifacts = &ArtifactsService{client: client}
 	client.Platform = &PlatformService{client: client}
@@ -65,7 +80,14 @@ func (c *Client) NewRequest(method, path string, body io.Reader) (*http.Request,
 }
 
 // Execute an HTTP request and decode the response into the provided variable.
+// Waits until the request is allowed by the configured limits. Safe for concurrent use.
 func (c *Client) Do(req *http.Request, v any) (*http.Response, error) {
+	release, err := c.limiterFor(req).acquire(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for request limit: %w", err)
+	}
+	defer release()
+
 	if c.debug {
 		fmt.Printf("Sending request: %s %s\n", req.Method, req.URL)
 		if req.Body != nil {
@@ -77,7 +99,7 @@ func (c *Client) Do(req *http.Request, v any) (*http.Response, error) {
 			req.Body = io.NopCloser(bytes.NewBuffer(bodyBytes))
 		}
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute request: %w", err)
 	}
@@ -108,6 +130,47 @@ func (c *Client) Do(req *http.Request, v any) (*http.Response, error) {
 	return resp, nil
 }
 
+// Execute an HTTP request and return the response with the unread body, e.g. to stream downloads.
+// The caller must close the body, the request counts against the configured limits until then.
+func (c *Client) DoRaw(req *http.Request) (*http.Response, error) {
+	release, err := c.limiterFor(req).acquire(req.Context())
+	if err != nil {
+		return nil, fmt.Errorf("failed to wait for request limit: %w", err)
+	}
+
+	if c.debug {
+		fmt.Printf("Sending request: %s %s\n", req.Method, req.URL)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		release()
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+
+	// Verify the response
+	err = c.checkResponse(resp)
+	if err != nil {
+		resp.Body.Close()
+		release()
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	resp.Body = &releasingReadCloser{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+// A body which releases the request limit once it is closed.
+type releasingReadCloser struct {
+	io.ReadCloser
+	release func()
+	once    sync.Once
+}
+
+func (r *releasingReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
+
 // Enable debugging (printing) of all received values.
 func (c *Client) SetDebug(debug bool) {
 	c.debug = debug
@@ -119,7 +182,17 @@ func (c *Client) checkResponse(resp *http.Response) error {
 	}
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status code %d: %s", resp.StatusCode, body)
+		return &statusCodeError{statusCode: resp.StatusCode, body: body}
 	}
 	return nil
 }
+
+// The error of a response with an unexpected status code.
+type statusCodeError struct {
+	statusCode int
+	body       []byte
+}
+
+func (e *statusCodeError) Error() string {
+	return fmt.Sprintf("unexpected status code %d: %s", e.statusCode, e.body)
+}
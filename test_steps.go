@@ -0,0 +1,210 @@
+package gotestguide
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Returned by a TestStepWalkFunc to skip the children of the current test step folder.
+var SkipTestStepFolder = errors.New("skip this test step folder")
+
+////////////////////////////////////////////////////////////
+// TestStepPath
+////////////////////////////////////////////////////////////
+
+// The names from the root of the tree down to a test step, including the name of the step itself.
+// When walking TestSteps, the first element is the section (Setup, Execution or Teardown).
+type TestStepPath []string
+
+func (p TestStepPath) String() string {
+	return strings.Join(p, " > ")
+}
+
+////////////////////////////////////////////////////////////
+// TestStepMatch
+////////////////////////////////////////////////////////////
+
+// A test step with its path in the tree.
+type TestStepMatch struct {
+	Path TestStepPath
+	Step IAbstractTestStep
+}
+
+func (m *TestStepMatch) String() string {
+	_, verdict := testStepInfo(m.Step)
+	return fmt.Sprintf("TestStepMatch(Path: %s, Type: %s, Verdict: %s)", m.Path, m.Step.GetType(), verdict)
+}
+
+////////////////////////////////////////////////////////////
+// Walking
+////////////////////////////////////////////////////////////
+
+// Called for each test step and test step folder of the tree, folders before their children.
+// Returning SkipTestStepFolder skips the children of a folder, any other error stops the walk and is returned.
+type TestStepWalkFunc func(path TestStepPath, step IAbstractTestStep) error
+
+// The sections of the test steps in the order they are executed.
+func (t *TestSteps) sections() []struct {
+	name  string
+	steps []IAbstractTestStep
+} {
+	return []struct {
+		name  string
+		steps []IAbstractTestStep
+	}{
+		{"Setup", t.Setup},
+		{"Execution", t.Execution},
+		{"Teardown", t.Teardown},
+	}
+}
+
+// Walks the setup, execution and teardown steps in order.
+func (t *TestSteps) Walk(fn TestStepWalkFunc) error {
+	for _, section := range t.sections() {
+		if err := walkTestSteps(TestStepPath{section.name}, section.steps, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Walks the given test steps in order, the paths start with the names of the given steps.
+func WalkTestSteps(steps []IAbstractTestStep, fn TestStepWalkFunc) error {
+	return walkTestSteps(TestStepPath{}, steps, fn)
+}
+
+func walkTestSteps(parent TestStepPath, steps []IAbstractTestStep, fn TestStepWalkFunc) error {
+	for _, step := range steps {
+		name, _ := testStepInfo(step)
+		// Use a full slice expression so that siblings do not share the backing array
+		path := append(parent[:len(parent):len(parent)], name)
+		err := fn(path, step)
+		if errors.Is(err, SkipTestStepFolder) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if folder := step.AsTestStepFolder(); folder != nil {
+			if err := walkTestSteps(path, folder.TestSteps, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////
+// Searching
+////////////////////////////////////////////////////////////
+
+// Gets all test steps and test step folders which match the predicate, in walk order.
+func (t *TestSteps) FindSteps(predicate func(path TestStepPath, step IAbstractTestStep) bool) []*TestStepMatch {
+	matches := []*TestStepMatch{}
+	t.Walk(func(path TestStepPath, step IAbstractTestStep) error {
+		if predicate(path, step) {
+			matches = append(matches, &TestStepMatch{Path: path, Step: step})
+		}
+		return nil
+	})
+	return matches
+}
+
+// Gets the first failed (FAILED or ERROR) test step. If a folder failed, the first failed step inside it is preferred.
+// Returns nil if no step failed.
+func (t *TestSteps) FirstFailedStep() *TestStepMatch {
+	for _, section := range t.sections() {
+		if match := firstFailedStep(TestStepPath{section.name}, section.steps); match != nil {
+			return match
+		}
+	}
+	return nil
+}
+
+func firstFailedStep(parent TestStepPath, steps []IAbstractTestStep) *TestStepMatch {
+	for _, step := range steps {
+		name, verdict := testStepInfo(step)
+		path := append(parent[:len(parent):len(parent)], name)
+		if folder := step.AsTestStepFolder(); folder != nil {
+			if match := firstFailedStep(path, folder.TestSteps); match != nil {
+				return match
+			}
+		}
+		if isFailingVerdict(verdict) {
+			return &TestStepMatch{Path: path, Step: step}
+		}
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////
+// Rendering
+////////////////////////////////////////////////////////////
+
+// Renders the test steps as indented text lines with their verdicts, grouped by section.
+func (t *TestSteps) Text() string {
+	var sb strings.Builder
+	for _, section := range t.sections() {
+		if len(section.steps) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "%s:\n", section.name)
+		WalkTestSteps(section.steps, func(path TestStepPath, step IAbstractTestStep) error {
+			name, verdict := testStepInfo(step)
+			fmt.Fprintf(&sb, "%s%s", strings.Repeat("  ", len(path)), name)
+			if verdict != "" {
+				fmt.Fprintf(&sb, " [%s]", verdict)
+			}
+			sb.WriteString("\n")
+			return nil
+		})
+	}
+	return sb.String()
+}
+
+// Renders the test steps as nested Markdown lists with verdict icons, grouped by section.
+func (t *TestSteps) Markdown() string {
+	var sb strings.Builder
+	for _, section := range t.sections() {
+		if len(section.steps) == 0 {
+			continue
+		}
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		fmt.Fprintf(&sb, "**%s**\n\n", section.name)
+		WalkTestSteps(section.steps, func(path TestStepPath, step IAbstractTestStep) error {
+			name, verdict := testStepInfo(step)
+			fmt.Fprintf(&sb, "%s- %s %s\n", strings.Repeat("  ", len(path)-1), verdictIcon(verdict), name)
+			return nil
+		})
+	}
+	return sb.String()
+}
+
+// Gets the icon which represents the verdict.
+func verdictIcon(verdict Verdict) string {
+	switch verdict {
+	case VERDICT_PASSED:
+		return "✅"
+	case VERDICT_FAILED:
+		return "❌"
+	case VERDICT_ERROR:
+		return "💥"
+	case VERDICT_INCONCLUSIVE:
+		return "⚠️"
+	}
+	return "⚪"
+}
+
+// Gets the name and the verdict of a test step or test step folder.
+func testStepInfo(step IAbstractTestStep) (string, Verdict) {
+	if folder := step.AsTestStepFolder(); folder != nil {
+		return folder.Name, folder.Verdict
+	}
+	if testStep := step.AsTestStep(); testStep != nil {
+		return testStep.Name, testStep.Verdict
+	}
+	return "", ""
+}
@@ -0,0 +1,215 @@
+package gotestguide
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestStepsTree() *TestSteps {
+	return &TestSteps{
+		Setup: []IAbstractTestStep{
+			&TestStep{Name: "Power on", Verdict: "PASSED"},
+		},
+		Execution: []IAbstractTestStep{
+			&TestStepFolder{Name: "Accelerate", Verdict: VERDICT_PASSED, TestSteps: []IAbstractTestStep{
+				&TestStep{Name: "Set pedal", Verdict: "PASSED"},
+			}},
+			&TestStepFolder{Name: "Brake", Verdict: VERDICT_FAILED, TestSteps: []IAbstractTestStep{
+				&TestStep{Name: "Press pedal", Verdict: "PASSED"},
+				&TestStep{Name: "Check speed", Verdict: "FAILED"},
+			}},
+			&TestStep{Name: "Log", Verdict: "ERROR"},
+		},
+		Teardown: []IAbstractTestStep{
+			&TestStep{Name: "Power off"},
+		},
+	}
+}
+
+func TestTestSteps_Walk(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := getTestStepsTree()
+
+	// Execute
+	paths := []string{}
+	err := steps.Walk(func(path TestStepPath, step IAbstractTestStep) error {
+		paths = append(paths, path.String())
+		if folder := step.AsTestStepFolder(); folder != nil && folder.Name == "Accelerate" {
+			return SkipTestStepFolder
+		}
+		return nil
+	})
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal([]string{
+		"Setup > Power on",
+		"Execution > Accelerate",
+		"Execution > Brake",
+		"Execution > Brake > Press pedal",
+		"Execution > Brake > Check speed",
+		"Execution > Log",
+		"Teardown > Power off",
+	}, paths)
+}
+
+func TestTestSteps_Walk_Stop(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := getTestStepsTree()
+	stop := errors.New("stop")
+
+	// Execute
+	visited := 0
+	err := steps.Walk(func(path TestStepPath, step IAbstractTestStep) error {
+		visited++
+		if len(path) == 3 {
+			return stop
+		}
+		return nil
+	})
+
+	// Verify
+	assert.ErrorIs(err, stop)
+	assert.Equal(3, visited)
+}
+
+func TestTestSteps_FindSteps(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := getTestStepsTree()
+
+	// Execute
+	matches := steps.FindSteps(func(path TestStepPath, step IAbstractTestStep) bool {
+		return step.GetType() == TEST_STEP_TYPE_TEST_STEP && path[0] == "Execution"
+	})
+
+	// Verify
+	assert.Len(matches, 4)
+	assert.Equal(TestStepPath{"Execution", "Accelerate", "Set pedal"}, matches[0].Path)
+	assert.Equal(TestStepPath{"Execution", "Brake", "Press pedal"}, matches[1].Path)
+	assert.Equal("Log", matches[3].Step.AsTestStep().Name)
+}
+
+func TestTestSteps_FirstFailedStep(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := getTestStepsTree()
+
+	// Execute
+	match := steps.FirstFailedStep()
+	none := (&TestSteps{Setup: steps.Setup}).FirstFailedStep()
+
+	// Verify
+	assert.NotNil(match)
+	assert.Equal(TestStepPath{"Execution", "Brake", "Check speed"}, match.Path, "Should prefer the step inside the failed folder")
+	assert.Nil(none)
+}
+
+func TestTestSteps_Text(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := getTestStepsTree()
+
+	// Execute
+	text := steps.Text()
+
+	// Verify
+	assert.Equal(`Setup:
+  Power on [PASSED]
+Execution:
+  Accelerate [PASSED]
+    Set pedal [PASSED]
+  Brake [FAILED]
+    Press pedal [PASSED]
+    Check speed [FAILED]
+  Log [ERROR]
+Teardown:
+  Power off
+`, text)
+}
+
+func TestTestSteps_Markdown(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := &TestSteps{Execution: getTestStepsTree().Execution[1:]}
+
+	// Execute
+	markdown := steps.Markdown()
+
+	// Verify
+	assert.Equal(`**Execution**
+
+- ❌ Brake
+  - ✅ Press pedal
+  - ❌ Check speed
+- 💥 Log
+`, markdown)
+}
+
+func TestTestSteps_JsonRoundTrip(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	steps := &TestSteps{
+		Setup: []IAbstractTestStep{
+			&TestStep{Name: "Power on", Verdict: VERDICT_PASSED, Timestamp: 1700000000000, ExecutionTime: 2},
+		},
+		Execution: []IAbstractTestStep{
+			&TestStepFolder{
+				Name:          "Brake",
+				Verdict:       VERDICT_FAILED,
+				Timestamp:     1700000002000,
+				ExecutionTime: 5,
+				Parameters:    []*Argument{{Name: "speed", Value: "50", Direction: DIRECTION_IN}},
+				TestSteps: []IAbstractTestStep{
+					&TestStep{
+						Name:              "Check speed",
+						Verdict:           VERDICT_FAILED,
+						ExpectedResult:    "0 km/h",
+						Result:            "3 km/h",
+						TestStepArtifacts: []*TestStepArtifact{{Path: "plots/speed.png", ArtifactType: TEST_STEP_ARTIFACT_TYPE_PLOT}},
+					},
+				},
+			},
+		},
+		Teardown: []IAbstractTestStep{
+			&TestStep{Name: "Power off", Verdict: VERDICT_NONE},
+		},
+	}
+
+	// Execute
+	data, err := json.Marshal(steps)
+	assert.NoError(err, "Should not return an error")
+	decoded := &TestSteps{}
+	err = json.Unmarshal(data, decoded)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Contains(string(data), `"@type":"teststepfolder"`)
+	assert.Equal(steps, decoded)
+}
+
+func TestTestSteps_UnmarshalDType(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	data := `{"execution": [{"dType": "TestStepFolder", "name": "Brake", "verdict": "PASSED", "teststeps": [
+		{"dType": "TestStep", "name": "Check", "verdict": "PASSED", "result": "0", "timestamp": 1700000000000}
+	]}]}`
+
+	// Execute
+	steps := &TestSteps{}
+	err := json.Unmarshal([]byte(data), steps)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	folder := steps.Execution[0].AsTestStepFolder()
+	assert.Equal("Brake", folder.Name)
+	step := folder.TestSteps[0].AsTestStep()
+	assert.Equal(VERDICT_PASSED, step.Verdict)
+	assert.Equal("0", step.Result)
+	assert.Equal(int64(1700000000000), step.Timestamp)
+}
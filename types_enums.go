@@ -1,5 +1,7 @@
 package gotestguide
 
+import "strings"
+
 ////////////////////////////////////////////////////////////
 // AwsS3StorageClass
 ////////////////////////////////////////////////////////////
@@ -28,6 +30,20 @@ const (
 	AZURE_AUTHENTICATION_TYPE_SHARED_KEY AzureAuthenticationType = "SHARED_KEY"
 )
 
+////////////////////////////////////////////////////////////
+// CISystem
+////////////////////////////////////////////////////////////
+
+type CISystem string
+
+const (
+	CI_SYSTEM_GITHUB_ACTIONS  CISystem = "GITHUB_ACTIONS"
+	CI_SYSTEM_GITLAB_CI       CISystem = "GITLAB_CI"
+	CI_SYSTEM_JENKINS         CISystem = "JENKINS"
+	CI_SYSTEM_AZURE_PIPELINES CISystem = "AZURE_PIPELINES"
+	CI_SYSTEM_TEAMCITY        CISystem = "TEAMCITY"
+)
+
 ////////////////////////////////////////////////////////////
 // Direction
 ////////////////////////////////////////////////////////////
@@ -59,6 +75,18 @@ const (
 	PROJECT_DELETED_STATE_FINISHED    ProjectDeletedState = "FINISHED"
 )
 
+////////////////////////////////////////////////////////////
+// PruneKeepReason
+////////////////////////////////////////////////////////////
+
+type PruneKeepReason string
+
+const (
+	PRUNE_KEEP_REASON_LATEST   PruneKeepReason = "LATEST"
+	PRUNE_KEEP_REASON_RELEASED PruneKeepReason = "RELEASED"
+	PRUNE_KEEP_REASON_REVIEWED PruneKeepReason = "REVIEWED"
+)
+
 ////////////////////////////////////////////////////////////
 // ReportStatus
 ////////////////////////////////////////////////////////////
@@ -112,6 +140,18 @@ const (
 	SMB_DIALECT_3_1_1 SmbDialect = "SMB_3_1_1"
 )
 
+////////////////////////////////////////////////////////////
+// SpoolEntryType
+////////////////////////////////////////////////////////////
+
+type SpoolEntryType string
+
+const (
+	SPOOL_ENTRY_TYPE_REPORT       SpoolEntryType = "REPORT"
+	SPOOL_ENTRY_TYPE_TYPED_REPORT SpoolEntryType = "TYPED_REPORT"
+	SPOOL_ENTRY_TYPE_ARTIFACT     SpoolEntryType = "ARTIFACT"
+)
+
 ////////////////////////////////////////////////////////////
 // StorageType
 ////////////////////////////////////////////////////////////
@@ -127,6 +167,67 @@ const (
 	STORAGE_TYPE_AZUREBLOB   StorageType = "azureBlobStorage"
 )
 
+////////////////////////////////////////////////////////////
+// StatisticsInterval
+////////////////////////////////////////////////////////////
+
+type StatisticsInterval string
+
+const (
+	STATISTICS_INTERVAL_DAY  StatisticsInterval = "day"
+	STATISTICS_INTERVAL_WEEK StatisticsInterval = "week"
+)
+
+////////////////////////////////////////////////////////////
+// TaskStatus
+////////////////////////////////////////////////////////////
+
+type TaskStatus string
+
+const (
+	TASK_STATUS_WAITING  TaskStatus = "waiting"
+	TASK_STATUS_RUNNING  TaskStatus = "running"
+	TASK_STATUS_FINISHED TaskStatus = "finished"
+	TASK_STATUS_ERROR    TaskStatus = "error"
+)
+
+// Checks if the task is done (successfully or not).
+func (s TaskStatus) IsDone() bool {
+	return s.IsFinished() || strings.EqualFold(string(s), string(TASK_STATUS_ERROR))
+}
+
+// Checks if the task finished successfully.
+func (s TaskStatus) IsFinished() bool {
+	return strings.EqualFold(string(s), string(TASK_STATUS_FINISHED))
+}
+
+////////////////////////////////////////////////////////////
+// TestCaseDiffStatus
+////////////////////////////////////////////////////////////
+
+type TestCaseDiffStatus string
+
+const (
+	TEST_CASE_DIFF_STATUS_NEW_FAILURE     TestCaseDiffStatus = "NEW_FAILURE"
+	TEST_CASE_DIFF_STATUS_FIXED           TestCaseDiffStatus = "FIXED"
+	TEST_CASE_DIFF_STATUS_STILL_FAILING   TestCaseDiffStatus = "STILL_FAILING"
+	TEST_CASE_DIFF_STATUS_CHANGED_VERDICT TestCaseDiffStatus = "CHANGED_VERDICT"
+	TEST_CASE_DIFF_STATUS_UNCHANGED       TestCaseDiffStatus = "UNCHANGED"
+	TEST_CASE_DIFF_STATUS_ADDED           TestCaseDiffStatus = "ADDED"
+	TEST_CASE_DIFF_STATUS_REMOVED         TestCaseDiffStatus = "REMOVED"
+)
+
+////////////////////////////////////////////////////////////
+// TestStepArtifactType
+////////////////////////////////////////////////////////////
+
+type TestStepArtifactType string
+
+const (
+	TEST_STEP_ARTIFACT_TYPE_IMAGE TestStepArtifactType = "IMAGE"
+	TEST_STEP_ARTIFACT_TYPE_PLOT  TestStepArtifactType = "PLOT"
+)
+
 ////////////////////////////////////////////////////////////
 // TestStepType
 ////////////////////////////////////////////////////////////
@@ -154,6 +154,11 @@ func (d *DeleteStatus) String() string {
 	return fmt.Sprintf("DeleteStatus(Status: %s, DetailedMessage: %s)", d.Status, d.DetailedMessage)
 }
 
+// Checks if the delete task is done (successfully or not).
+func (d *DeleteStatus) IsDone() bool {
+	return TaskStatus(d.Status).IsDone()
+}
+
 ////////////////////////////////////////////////////////////
 // Depository
 ////////////////////////////////////////////////////////////
@@ -206,7 +211,7 @@ func (f *FileReference) String() string {
 ////////////////////////////////////////////////////////////
 
 type Filter struct {
-	FilterId    int64             `json:"filterId"`
+	FilterId    int64             `json:"filterId,omitempty"`
 	Name        string            `json:"name"`
 	Category    string            `json:"category,omitempty"`
 	Description string            `json:"description,omitempty"`
@@ -268,6 +273,19 @@ type FilterParameters struct {
 	ReviewTickets          []string            `json:"reviewTickets"`
 }
 
+// Checks if the filter does not restrict the test case executions, i.e. it matches all of them.
+// IncludeObsoleteReviews only widens the review criteria, so it does not count.
+func (f *FilterParameters) isEmpty() bool {
+	return f.TestCaseTagSetID == nil && len(f.TestSuiteName) == 0 && len(f.TestCaseName) == 0 && len(f.ParameterSetName) == 0 &&
+		len(f.TestEnvironments) == 0 && len(f.Attributes) == 0 && len(f.Constants) == 0 &&
+		f.ExecutionTimeMin == nil && f.ExecutionTimeMax == nil && len(f.PlannedTestCaseFolder) == 0 &&
+		f.DateFrom == nil && f.DateTo == nil && len(f.ArchiveFiles) == 0 && f.TestArgumentExpr == "" &&
+		len(f.TestArgumentDirections) == 0 && len(f.AtxIds) == 0 && len(f.Verdicts) == 0 &&
+		f.ReviewExists == "" && f.ReviewAuthor == "" && f.ReviewComment == "" && f.ReviewSummary == "" &&
+		len(f.ReviewVerdicts) == 0 && f.InvalidRuns == nil && f.ReviewDefectClass == "" && f.ReviewDefectPriority == "" &&
+		len(f.ReviewTags) == 0 && f.ReviewCustomEvaluation == "" && len(f.ReviewTickets) == 0
+}
+
 ////////////////////////////////////////////////////////////
 // KeyValuesFilter
 ////////////////////////////////////////////////////////////
@@ -337,6 +355,51 @@ func (r *Recording) String() string {
 	return fmt.Sprintf("Recording(Name: %s, Direction: %s, FileHash: %s)", r.Name, r.Direction, r.FileHash)
 }
 
+////////////////////////////////////////////////////////////
+// Release
+////////////////////////////////////////////////////////////
+
+type Release struct {
+	ID          int64      `json:"id,omitzero"`
+	ProjectID   int        `json:"projectId,omitzero"`
+	Name        string     `json:"name"`
+	Description string     `json:"description,omitempty"`
+	Version     string     `json:"version,omitempty"`
+	StartDate   *time.Time `json:"startDate,omitempty"`
+	DueDate     *time.Time `json:"dueDate,omitempty"`
+	Closed      bool       `json:"closed"`
+	ClosedDate  *time.Time `json:"closedDate,omitempty"`
+}
+
+func (r *Release) String() string {
+	return fmt.Sprintf("Release(ID: %d, ProjectID: %d, Name: %s, Version: %s, Closed: %t)", r.ID, r.ProjectID, r.Name, r.Version, r.Closed)
+}
+
+////////////////////////////////////////////////////////////
+// ReleaseProgress
+////////////////////////////////////////////////////////////
+
+type ReleaseProgress struct {
+	ReleaseID          int64           `json:"releaseId"`
+	Reports            int             `json:"reports"`
+	TestCaseExecutions int             `json:"testCaseExecutions"`
+	Reviewed           int             `json:"reviewed"`
+	Verdicts           map[Verdict]int `json:"verdicts"`
+}
+
+func (r *ReleaseProgress) String() string {
+	return fmt.Sprintf("ReleaseProgress(ReleaseID: %d, Reports: %d, TestCaseExecutions: %d, Reviewed: %d, Verdicts: %v)",
+		r.ReleaseID, r.Reports, r.TestCaseExecutions, r.Reviewed, r.Verdicts)
+}
+
+// Gets the share of test case executions with a PASSED verdict between 0 and 1.
+func (r *ReleaseProgress) PassRate() float64 {
+	if r.TestCaseExecutions == 0 {
+		return 0
+	}
+	return float64(r.Verdicts[VERDICT_PASSED]) / float64(r.TestCaseExecutions)
+}
+
 ////////////////////////////////////////////////////////////
 // ReportHistoryItem
 ////////////////////////////////////////////////////////////
@@ -360,15 +423,15 @@ func (r *ReportHistoryItem) String() string {
 ////////////////////////////////////////////////////////////
 
 type Review struct {
-	ID               int64            `json:"id"`
-	ProjectID        int              `json:"projectId"`
-	Attachments      []*FileReference `json:"attachments"`
+	ID               int64            `json:"id,omitzero"`
+	ProjectID        int              `json:"projectId,omitzero"`
+	Attachments      []*FileReference `json:"attachments,omitempty"`
 	Summary          string           `json:"summary,omitempty"`
 	Comment          string           `json:"comment"`
 	Verdict          Verdict          `json:"verdict,omitempty"`
 	CustomEvaluation string           `json:"customEvaluation,omitempty"`
-	Reviewer         string           `json:"reviewer"`
-	ReviewDate       time.Time        `json:"reviewDate"`
+	Reviewer         string           `json:"reviewer,omitempty"`
+	ReviewDate       time.Time        `json:"reviewDate,omitzero"`
 	Contacts         []string         `json:"contacts,omitempty"`
 	Tickets          []string         `json:"tickets,omitempty"`
 	Tags             []string         `json:"tags,omitempty"`
@@ -425,6 +488,14 @@ func (t *TestCaseExecution) String() string {
 		t.ID, t.ProjectID, t.ReportID, t.Verdict)
 }
 
+// Gets the effective verdict (including reviews) or the original verdict if no effective verdict is set.
+func (t *TestCaseExecution) GetEffectiveVerdict() Verdict {
+	if t.EffectiveVerdict != "" {
+		return t.EffectiveVerdict
+	}
+	return t.Verdict
+}
+
 ////////////////////////////////////////////////////////////
 // TestCaseExecutionLink
 ////////////////////////////////////////////////////////////
@@ -505,45 +576,71 @@ func (t *TestSteps) UnmarshalJSON(data []byte) error {
 func unmarshalRawTestStep(raw []json.RawMessage) ([]IAbstractTestStep, error) {
 	ret := make([]IAbstractTestStep, len(raw))
 	for i, step := range raw {
-		// Get the type of the step
+		// Get the type of the step, the API returns it as "dType", uploads (and MarshalJSON) use "@type" in lower case
 		var stepType struct {
 			DType TestStepType `json:"dType"`
+			Type  TestStepType `json:"@type"`
 		}
 		if err := json.Unmarshal(step, &stepType); err != nil {
 			return nil, err
 		}
+		typeName := stepType.DType
+		if typeName == "" {
+			typeName = stepType.Type
+		}
 
 		// Handle the different types
-		switch stepType.DType {
-		case TEST_STEP_TYPE_TEST_STEP:
+		switch {
+		case strings.EqualFold(string(typeName), string(TEST_STEP_TYPE_TEST_STEP)):
 			var ts TestStep
 			if err := json.Unmarshal(step, &ts); err != nil {
 				return nil, err
 			}
 			ret[i] = &ts
-		case TEST_STEP_TYPE_TEST_STEP_FOLDER:
+		case strings.EqualFold(string(typeName), string(TEST_STEP_TYPE_TEST_STEP_FOLDER)):
 			var folder TestStepFolder
 			if err := json.Unmarshal(step, &folder); err != nil {
 				return nil, err
 			}
 			ret[i] = &folder
 		default:
-			return nil, fmt.Errorf("unknown type: %s", stepType.DType)
+			return nil, fmt.Errorf("unknown type: %s", typeName)
 		}
 	}
 	return ret, nil
 }
 
+////////////////////////////////////////////////////////////
+// TestStepArtifact
+////////////////////////////////////////////////////////////
+
+// A file attached to a single test step, e.g. a screenshot or a plot.
+type TestStepArtifact struct {
+	// Path of the file, relative to the uploaded report archive
+	Path         string               `json:"path"`
+	ArtifactType TestStepArtifactType `json:"artifactType,omitempty"`
+}
+
+func (a *TestStepArtifact) String() string {
+	return fmt.Sprintf("TestStepArtifact(Path: %s, ArtifactType: %s)", a.Path, a.ArtifactType)
+}
+
 ////////////////////////////////////////////////////////////
 // TestStepFolder
 ////////////////////////////////////////////////////////////
 
 type TestStepFolder struct {
-	Name           string              `json:"name"`
-	Description    string              `json:"description,omitempty"`
-	Verdict        Verdict             `json:"verdict,omitempty"`
-	ExpectedResult string              `json:"expectedResult,omitempty"`
-	TestSteps      []IAbstractTestStep `json:"teststeps"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description,omitempty"`
+	Verdict        Verdict `json:"verdict,omitempty"`
+	ExpectedResult string  `json:"expectedResult,omitempty"`
+	// Start of the folder in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Execution time in seconds
+	ExecutionTime     int                 `json:"executionTime,omitempty"`
+	Parameters        []*Argument         `json:"parameters,omitempty"`
+	TestStepArtifacts []*TestStepArtifact `json:"testStepArtifacts,omitempty"`
+	TestSteps         []IAbstractTestStep `json:"teststeps"`
 }
 
 func (f *TestStepFolder) String() string {
@@ -600,15 +697,23 @@ func (f *TestStepFolder) MarshalJSON() ([]byte, error) {
 ////////////////////////////////////////////////////////////
 
 type TestStep struct {
-	Name           string `json:"name"`
-	Description    string `json:"description,omitempty"`
-	Verdict        string `json:"verdict,omitempty"`
-	ExpectedResult string `json:"expectedResult,omitempty"`
+	Name           string  `json:"name"`
+	Description    string  `json:"description,omitempty"`
+	Verdict        Verdict `json:"verdict,omitempty"`
+	ExpectedResult string  `json:"expectedResult,omitempty"`
+	// The actual result or measured value of the step
+	Result string `json:"result,omitempty"`
+	// Start of the step in milliseconds since the epoch
+	Timestamp int64 `json:"timestamp,omitempty"`
+	// Execution time in seconds
+	ExecutionTime     int                 `json:"executionTime,omitempty"`
+	Parameters        []*Argument         `json:"parameters,omitempty"`
+	TestStepArtifacts []*TestStepArtifact `json:"testStepArtifacts,omitempty"`
 }
 
 func (f *TestStep) String() string {
-	return fmt.Sprintf("TestStep(Name: %s, Verdict: %s, ExpectedResult: %s)",
-		f.Name, f.Verdict, f.ExpectedResult)
+	return fmt.Sprintf("TestStep(Name: %s, Verdict: %s, ExpectedResult: %s, Result: %s)",
+		f.Name, f.Verdict, f.ExpectedResult, f.Result)
 }
 
 func (f *TestStep) GetType() TestStepType {
@@ -694,3 +799,16 @@ func (u *UploadStatus) String() string {
 	return fmt.Sprintf("UploadStatus(Status: %s, UploadReturnCode: %d, ReportID: %d, IsDoubleUpload: %t, ResultMessages: %s)",
 		u.Status, u.UploadResult.UploadReturnCode, u.UploadResult.ReportID, u.UploadResult.IsDoubleUpload, strings.Join(u.UploadResult.ResultMessages, "|"))
 }
+
+// Checks if the upload task is done (successfully or not).
+func (u *UploadStatus) IsDone() bool {
+	return TaskStatus(u.Status).IsDone()
+}
+
+// Checks if the upload is done and created a report (or found an identical one).
+func (u *UploadStatus) IsSuccessful() bool {
+	if !TaskStatus(u.Status).IsFinished() {
+		return false
+	}
+	return u.UploadResult.ReportID > 0 || u.UploadResult.IsDoubleUpload
+}
@@ -1,7 +1,14 @@
 package gotestguide
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -18,6 +25,239 @@ type UploadReport struct {
 	OptionalReportIdentifier string                    `json:"optionalReportIdentifier,omitempty"`
 }
 
+// Custom unmarshal function to handle the different types of test cases.
+func (r *UploadReport) UnmarshalJSON(data []byte) error {
+	type Alias UploadReport
+	var raw struct {
+		TestCases []json.RawMessage `json:"testcases"`
+		*Alias
+	}
+	raw.Alias = (*Alias)(r)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if testCases, err := unmarshalRawUploadTestCases(raw.TestCases); err != nil {
+		return err
+	} else {
+		r.TestCases = testCases
+	}
+
+	return nil
+}
+
+// Gets all test cases of the report, including the ones in (nested) folders.
+func (r *UploadReport) GetAllTestCases() []*UploadTestCase {
+	return collectUploadTestCases(r.TestCases)
+}
+
+func collectUploadTestCases(testCases []IAbstractUploadTestCase) []*UploadTestCase {
+	ret := []*UploadTestCase{}
+	for _, testCase := range testCases {
+		if folder := testCase.AsTestCaseFolder(); folder != nil {
+			ret = append(ret, collectUploadTestCases(folder.TestCases)...)
+		} else if tc := testCase.AsTestCase(); tc != nil {
+			ret = append(ret, tc)
+		}
+	}
+	return ret
+}
+
+// Helper method to unmarshal raw test cases into the appropriate types.
+func unmarshalRawUploadTestCases(raw []json.RawMessage) ([]IAbstractUploadTestCase, error) {
+	ret := make([]IAbstractUploadTestCase, len(raw))
+	for i, testCase := range raw {
+		// Get the type of the test case
+		var testCaseType struct {
+			Type TestCaseType `json:"@type"`
+		}
+		if err := json.Unmarshal(testCase, &testCaseType); err != nil {
+			return nil, err
+		}
+
+		// Handle the different types
+		switch {
+		case strings.EqualFold(string(testCaseType.Type), string(TEST_CASE_TYPE_TEST_CASE)):
+			var tc UploadTestCase
+			if err := json.Unmarshal(testCase, &tc); err != nil {
+				return nil, err
+			}
+			ret[i] = &tc
+		case strings.EqualFold(string(testCaseType.Type), string(TEST_CASE_TYPE_TEST_CASE_FOLDER)):
+			var folder UploadTestCaseFolder
+			if err := json.Unmarshal(testCase, &folder); err != nil {
+				return nil, err
+			}
+			ret[i] = &folder
+		default:
+			return nil, fmt.Errorf("unknown type: %s", testCaseType.Type)
+		}
+	}
+	return ret, nil
+}
+
+// Derives a deterministic OptionalReportIdentifier from the content of the report and the ID of the pipeline which created it.
+// Uploading the same report from the same pipeline again is then detected by the server as double upload.
+func (r *UploadReport) DeriveIdentifier(pipelineId string) (string, error) {
+	content := *r
+	content.OptionalReportIdentifier = ""
+	contentBytes, err := json.Marshal(&content)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal report: %w", err)
+	}
+	hash := sha256.New()
+	hash.Write([]byte(pipelineId))
+	hash.Write([]byte{0})
+	hash.Write(contentBytes)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Gets the report with an identifier derived with DeriveIdentifier. A report which already has an identifier is returned
+// unchanged, otherwise the identifier is set on a copy to leave the report unchanged.
+func (r *UploadReport) WithDerivedIdentifier(pipelineId string) (*UploadReport, error) {
+	if r.OptionalReportIdentifier != "" {
+		return r, nil
+	}
+	identifier, err := r.DeriveIdentifier(pipelineId)
+	if err != nil {
+		return nil, err
+	}
+	reportCopy := *r
+	reportCopy.OptionalReportIdentifier = identifier
+	return &reportCopy, nil
+}
+
+// Reads a typed report from a JSON file in the json2atx format.
+func ReadUploadReport(path string) (*UploadReport, error) {
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	report := &UploadReport{}
+	if err := json.Unmarshal(fileBytes, report); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	return report, nil
+}
+
+// Reads a typed report like ReadUploadReport, but fails if the file contains values which are not part of the
+// typed report. Use this before the report is changed and uploaded again, as these values would be lost.
+func ReadUploadReportStrict(path string) (*UploadReport, error) {
+	report, err := ReadUploadReport(path)
+	if err != nil {
+		return nil, err
+	}
+	fileBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report %s: %w", path, err)
+	}
+	typedBytes, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal report: %w", err)
+	}
+	var original, typed any
+	if err := json.Unmarshal(fileBytes, &original); err != nil {
+		return nil, fmt.Errorf("failed to parse report %s: %w", path, err)
+	}
+	if err := json.Unmarshal(typedBytes, &typed); err != nil {
+		return nil, fmt.Errorf("failed to parse report: %w", err)
+	}
+	if dropped := findDroppedJsonFields("", original, typed); len(dropped) > 0 {
+		return nil, fmt.Errorf("report %s contains unsupported fields: %s", path, strings.Join(dropped, ", "))
+	}
+	return report, nil
+}
+
+// Gets the paths of all fields with a non-empty value in the original JSON which are missing in the other JSON.
+func findDroppedJsonFields(fieldPath string, original any, other any) []string {
+	dropped := []string{}
+	switch originalValue := original.(type) {
+	case map[string]any:
+		otherMap, _ := other.(map[string]any)
+		keys := make([]string, 0, len(originalValue))
+		for key := range originalValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value := originalValue[key]
+			otherValue, exists := otherMap[key]
+			if !exists {
+				if !isEmptyJsonValue(value) {
+					dropped = append(dropped, strings.TrimPrefix(fieldPath+"."+key, "."))
+				}
+				continue
+			}
+			dropped = append(dropped, findDroppedJsonFields(strings.TrimPrefix(fieldPath+"."+key, "."), value, otherValue)...)
+		}
+	case []any:
+		otherSlice, _ := other.([]any)
+		for i, value := range originalValue {
+			if i >= len(otherSlice) {
+				if !isEmptyJsonValue(value) {
+					dropped = append(dropped, fmt.Sprintf("%s[%d]", fieldPath, i))
+				}
+				continue
+			}
+			dropped = append(dropped, findDroppedJsonFields(fmt.Sprintf("%s[%d]", fieldPath, i), value, otherSlice[i])...)
+		}
+	}
+	return dropped
+}
+
+// Checks if a JSON value is empty, these values are omitted when the typed report is written.
+func isEmptyJsonValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// Gets the slash-separated paths of all files which are referenced by the test cases and test steps of the report.
+// The paths are relative to the report file and each path is only returned once.
+func (r *UploadReport) GetReferencedFiles() []string {
+	files := []string{}
+	seen := map[string]bool{}
+	add := func(file string) {
+		file = path.Clean(filepath.ToSlash(file))
+		if !seen[file] {
+			seen[file] = true
+			files = append(files, file)
+		}
+	}
+	for _, testCase := range r.GetAllTestCases() {
+		for _, artifact := range testCase.Artifacts {
+			add(artifact)
+		}
+		for _, steps := range [][]IAbstractTestStep{testCase.SetupTestSteps, testCase.ExecutionTestSteps, testCase.TeardownTestSteps} {
+			WalkTestSteps(steps, func(_ TestStepPath, step IAbstractTestStep) error {
+				var artifacts []*TestStepArtifact
+				if folder := step.AsTestStepFolder(); folder != nil {
+					artifacts = folder.TestStepArtifacts
+				} else if testStep := step.AsTestStep(); testStep != nil {
+					artifacts = testStep.TestStepArtifacts
+				}
+				for _, artifact := range artifacts {
+					add(artifact.Path)
+				}
+				return nil
+			})
+		}
+	}
+	return files
+}
+
 type IAbstractUploadTestCase interface {
 	GetType() TestCaseType
 	AsTestCase() *UploadTestCase
@@ -41,6 +281,27 @@ func (f *UploadTestCaseFolder) AsTestCaseFolder() *UploadTestCaseFolder {
 	return f
 }
 
+// Custom unmarshal function to handle the different types of test cases.
+func (f *UploadTestCaseFolder) UnmarshalJSON(data []byte) error {
+	type Alias UploadTestCaseFolder
+	var raw struct {
+		TestCases []json.RawMessage `json:"testcases"`
+		*Alias
+	}
+	raw.Alias = (*Alias)(f)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if testCases, err := unmarshalRawUploadTestCases(raw.TestCases); err != nil {
+		return err
+	} else {
+		f.TestCases = testCases
+	}
+
+	return nil
+}
+
 func (f *UploadTestCaseFolder) MarshalJSON() ([]byte, error) {
 	type Alias UploadTestCaseFolder
 	return json.Marshal(&struct {
@@ -86,6 +347,41 @@ func (f *UploadTestCase) AsTestCaseFolder() *UploadTestCaseFolder {
 	return nil
 }
 
+// Custom unmarshal function to handle the different types of test steps.
+func (f *UploadTestCase) UnmarshalJSON(data []byte) error {
+	type Alias UploadTestCase
+	var raw struct {
+		SetupTestSteps     []json.RawMessage `json:"setupTestSteps"`
+		ExecutionTestSteps []json.RawMessage `json:"executionTestSteps"`
+		TeardownTestSteps  []json.RawMessage `json:"teardownTestSteps"`
+		*Alias
+	}
+	raw.Alias = (*Alias)(f)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	if steps, err := unmarshalRawTestStep(raw.SetupTestSteps); err != nil {
+		return err
+	} else if len(steps) > 0 {
+		f.SetupTestSteps = steps
+	}
+
+	if steps, err := unmarshalRawTestStep(raw.ExecutionTestSteps); err != nil {
+		return err
+	} else if len(steps) > 0 {
+		f.ExecutionTestSteps = steps
+	}
+
+	if steps, err := unmarshalRawTestStep(raw.TeardownTestSteps); err != nil {
+		return err
+	} else if len(steps) > 0 {
+		f.TeardownTestSteps = steps
+	}
+
+	return nil
+}
+
 func (f *UploadTestCase) MarshalJSON() ([]byte, error) {
 	type Alias UploadTestCase
 	return json.Marshal(&struct {
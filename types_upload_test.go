@@ -0,0 +1,133 @@
+package gotestguide
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadReport_JsonRoundTrip(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	report := &UploadReport{
+		Name:      "Report",
+		Timestamp: 1700000000000,
+		TestCases: []IAbstractUploadTestCase{
+			&UploadTestCaseFolder{Name: "Folder", TestCases: []IAbstractUploadTestCase{
+				&UploadTestCase{
+					Name:               "Test 1",
+					Verdict:            VERDICT_PASSED,
+					Timestamp:          1700000000000,
+					ExecutionTestSteps: []IAbstractTestStep{&TestStep{Name: "Step", Verdict: VERDICT_PASSED}},
+				},
+			}},
+		},
+	}
+	path := filepath.Join(t.TempDir(), "report.json")
+	assert.NoError(writeJsonFile(path, report))
+
+	// Execute
+	decoded, err := ReadUploadReport(path)
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Equal(report, decoded)
+	assert.Len(decoded.GetAllTestCases(), 1)
+}
+
+func TestUploadReport_ReadStrict(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	dir := t.TempDir()
+	supportedPath := filepath.Join(dir, "supported.json")
+	assert.NoError(os.WriteFile(supportedPath, []byte(`{"name": "Report", "timestamp": 1, "testcases": [
+		{"@type": "testcase", "name": "Test", "verdict": "PASSED", "timestamp": 1, "description": "", "artifacts": []}
+	]}`), 0644))
+	unsupportedPath := filepath.Join(dir, "unsupported.json")
+	assert.NoError(os.WriteFile(unsupportedPath, []byte(`{"name": "Report", "timestamp": 1, "testcases": [
+		{"@type": "testcase", "name": "Test", "verdict": "PASSED", "timestamp": 1, "customField": "value"}
+	]}`), 0644))
+
+	// Execute
+	report, supportedErr := ReadUploadReportStrict(supportedPath)
+	_, unsupportedErr := ReadUploadReportStrict(unsupportedPath)
+
+	// Verify
+	assert.NoError(supportedErr, "Empty values should not be treated as unsupported")
+	assert.Equal("Report", report.Name)
+	assert.ErrorContains(unsupportedErr, "testcases[0].customField", "Should name the unsupported field")
+}
+
+func TestUploadReport_GetReferencedFiles(t *testing.T) {
+	// Prepare
+	report := &UploadReport{TestCases: []IAbstractUploadTestCase{
+		&UploadTestCaseFolder{Name: "Folder", TestCases: []IAbstractUploadTestCase{
+			&UploadTestCase{Name: "Test 1", Artifacts: []string{"logs/trace.asc", "./logs/trace.asc"}},
+		}},
+		&UploadTestCase{Name: "Test 2", SetupTestSteps: []IAbstractTestStep{
+			&TestStepFolder{Name: "Folder", TestStepArtifacts: []*TestStepArtifact{{Path: "folder.png"}}, TestSteps: []IAbstractTestStep{
+				&TestStep{Name: "Step", TestStepArtifacts: []*TestStepArtifact{{Path: "step.png"}}},
+			}},
+		}},
+	}}
+
+	// Execute
+	files := report.GetReferencedFiles()
+
+	// Verify
+	assert.Equal(t, []string{"logs/trace.asc", "folder.png", "step.png"}, files, "Should contain each file once")
+}
+
+func TestUploadReport_DeriveIdentifier(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	newReport := func() *UploadReport {
+		return &UploadReport{
+			Name:      "Report",
+			Timestamp: 1700000000000,
+			TestCases: []IAbstractUploadTestCase{&UploadTestCase{Name: "Test 1", Verdict: VERDICT_PASSED}},
+		}
+	}
+	changedReport := newReport()
+	changedReport.TestCases[0].AsTestCase().Verdict = VERDICT_FAILED
+	identifiedReport := newReport()
+	identifiedReport.OptionalReportIdentifier = "existing"
+
+	// Execute
+	identifier, err := newReport().DeriveIdentifier("1234")
+	sameIdentifier, _ := newReport().DeriveIdentifier("1234")
+	otherPipelineIdentifier, _ := newReport().DeriveIdentifier("1235")
+	changedIdentifier, _ := changedReport.DeriveIdentifier("1234")
+	ignoredIdentifier, _ := identifiedReport.DeriveIdentifier("1234")
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.Len(identifier, 64)
+	assert.Equal(identifier, sameIdentifier, "Should be deterministic")
+	assert.NotEqual(identifier, otherPipelineIdentifier, "Should depend on the pipeline")
+	assert.NotEqual(identifier, changedIdentifier, "Should depend on the content")
+	assert.Equal(identifier, ignoredIdentifier, "Should ignore an existing identifier")
+	assert.Equal("existing", identifiedReport.OptionalReportIdentifier, "Should not modify the report")
+}
+
+func TestUploadReport_WithDerivedIdentifier(t *testing.T) {
+	// Prepare
+	assert := assert.New(t)
+	report := &UploadReport{Name: "Report", Timestamp: 1700000000000}
+	identifiedReport := &UploadReport{Name: "Report", OptionalReportIdentifier: "existing"}
+	expectedIdentifier, err := report.DeriveIdentifier("1234")
+	assert.NoError(err)
+
+	// Execute
+	derived, err := report.WithDerivedIdentifier("1234")
+	unchanged, unchangedErr := identifiedReport.WithDerivedIdentifier("1234")
+
+	// Verify
+	assert.NoError(err, "Should not return an error")
+	assert.NoError(unchangedErr, "Should not return an error")
+	assert.Equal(expectedIdentifier, derived.OptionalReportIdentifier)
+	assert.Empty(report.OptionalReportIdentifier, "Should not modify the report")
+	assert.Same(identifiedReport, unchanged, "Should keep an existing identifier")
+}
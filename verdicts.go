@@ -0,0 +1,49 @@
+package gotestguide
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+////////////////////////////////////////////////////////////
+// This file contains helpers for verdicts and values which are shared by the exports and statistics.
+////////////////////////////////////////////////////////////
+
+// Joins a single value and multiple values into one string.
+func joinValues(value string, values []string) string {
+	if value != "" {
+		return strings.Join(append([]string{value}, values...), ", ")
+	}
+	return strings.Join(values, ", ")
+}
+
+// Gets the number of test case executions per effective verdict.
+func CountVerdicts(tces []*TestCaseExecution) map[Verdict]int {
+	counts := map[Verdict]int{}
+	for _, tce := range tces {
+		counts[tce.GetEffectiveVerdict()]++
+	}
+	return counts
+}
+
+// The order in which verdicts are shown in summaries.
+var verdictOrder = []Verdict{VERDICT_PASSED, VERDICT_FAILED, VERDICT_ERROR, VERDICT_INCONCLUSIVE, VERDICT_NONE}
+
+// Gets the verdicts sorted by their usual order, followed by unknown verdicts.
+func sortedVerdicts(counts map[Verdict]int) []Verdict {
+	ret := []Verdict{}
+	for _, verdict := range verdictOrder {
+		if counts[verdict] > 0 {
+			ret = append(ret, verdict)
+		}
+	}
+	others := []Verdict{}
+	for verdict := range counts {
+		if !slices.Contains(verdictOrder, verdict) {
+			others = append(others, verdict)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	return append(ret, others...)
+}